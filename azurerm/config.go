@@ -53,6 +53,9 @@ type ArmClient struct {
 	usingServicePrincipal bool
 	environment           azure.Environment
 
+	// when set, Storage Accounts must only allow HTTPS traffic
+	enforceStorageHTTPSTrafficOnly bool
+
 	StopContext context.Context
 
 	availSetClient         compute.AvailabilitySetsClient
@@ -117,6 +120,7 @@ type ArmClient struct {
 
 	storageServiceClient storage.AccountsClient
 	storageUsageClient   storage.UsageClient
+	storageAccountKeys   *storageAccountKeyCache
 
 	deploymentsClient resources.DeploymentsClient
 
@@ -243,6 +247,8 @@ func (c *Config) getArmClient() (*ArmClient, error) {
 		subscriptionId:        c.SubscriptionID,
 		environment:           env,
 		usingServicePrincipal: c.ClientSecret != "",
+
+		enforceStorageHTTPSTrafficOnly: c.EnforceStorageHTTPSTrafficOnly,
 	}
 
 	oauthConfig, err := adal.NewOAuthConfig(env.ActiveDirectoryEndpoint, c.TenantID)
@@ -534,6 +540,7 @@ func (c *Config) getArmClient() (*ArmClient, error) {
 	ssc.Authorizer = auth
 	ssc.Sender = sender
 	client.storageServiceClient = ssc
+	client.storageAccountKeys = newStorageAccountKeyCache()
 
 	suc := storage.NewUsageClientWithBaseURI(endpoint, c.SubscriptionID)
 	setUserAgent(&suc.Client)
@@ -783,6 +790,10 @@ func (c *ArmClient) registerKeyVaultClients(endpoint, subscriptionId string, aut
 }
 
 func (armClient *ArmClient) getKeyForStorageAccount(resourceGroupName, storageAccountName string) (string, bool, error) {
+	if key, ok := armClient.storageAccountKeys.get(resourceGroupName, storageAccountName); ok {
+		return key, true, nil
+	}
+
 	accountKeys, err := armClient.storageServiceClient.ListKeys(resourceGroupName, storageAccountName)
 	if accountKeys.StatusCode == http.StatusNotFound {
 		return "", false, nil
@@ -798,16 +809,26 @@ func (armClient *ArmClient) getKeyForStorageAccount(resourceGroupName, storageAc
 	}
 
 	keys := *accountKeys.Keys
-	return *keys[0].Value, true, nil
+	key := *keys[0].Value
+	armClient.storageAccountKeys.set(resourceGroupName, storageAccountName, key)
+	return key, true, nil
 }
 
-func (armClient *ArmClient) getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName string) (*mainStorage.BlobStorageClient, bool, error) {
-	key, accountExists, err := armClient.getKeyForStorageAccount(resourceGroupName, storageAccountName)
-	if err != nil {
-		return nil, accountExists, err
-	}
-	if accountExists == false {
-		return nil, false, nil
+// getStorageClientForStorageAccount returns a Data Plane client for the specified Storage Account. When an
+// Access Key is specified it's used as-is (allowing principals without the `listKeys` permission to manage
+// Data Plane resources), otherwise the key is retrieved via ListKeys - in which case we can also determine
+// if the Storage Account exists.
+func (armClient *ArmClient) getStorageClientForStorageAccount(resourceGroupName, storageAccountName, accountKey string) (*mainStorage.Client, bool, error) {
+	key := accountKey
+	if key == "" {
+		k, accountExists, err := armClient.getKeyForStorageAccount(resourceGroupName, storageAccountName)
+		if err != nil {
+			return nil, accountExists, err
+		}
+		if accountExists == false {
+			return nil, false, nil
+		}
+		key = k
 	}
 
 	storageClient, err := mainStorage.NewClient(storageAccountName, key, armClient.environment.StorageEndpointSuffix,
@@ -816,62 +837,44 @@ func (armClient *ArmClient) getBlobStorageClientForStorageAccount(resourceGroupN
 		return nil, true, fmt.Errorf("Error creating storage client for storage account %q: %s", storageAccountName, err)
 	}
 
-	blobClient := storageClient.GetBlobService()
-	return &blobClient, true, nil
+	return &storageClient, true, nil
 }
 
-func (armClient *ArmClient) getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName string) (*mainStorage.FileServiceClient, bool, error) {
-	key, accountExists, err := armClient.getKeyForStorageAccount(resourceGroupName, storageAccountName)
-	if err != nil {
+func (armClient *ArmClient) getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName, accountKey string) (*mainStorage.BlobStorageClient, bool, error) {
+	storageClient, accountExists, err := armClient.getStorageClientForStorageAccount(resourceGroupName, storageAccountName, accountKey)
+	if err != nil || !accountExists {
 		return nil, accountExists, err
 	}
-	if accountExists == false {
-		return nil, false, nil
-	}
 
-	storageClient, err := mainStorage.NewClient(storageAccountName, key, armClient.environment.StorageEndpointSuffix,
-		mainStorage.DefaultAPIVersion, true)
-	if err != nil {
-		return nil, true, fmt.Errorf("Error creating storage client for storage account %q: %s", storageAccountName, err)
+	blobClient := storageClient.GetBlobService()
+	return &blobClient, true, nil
+}
+
+func (armClient *ArmClient) getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName, accountKey string) (*mainStorage.FileServiceClient, bool, error) {
+	storageClient, accountExists, err := armClient.getStorageClientForStorageAccount(resourceGroupName, storageAccountName, accountKey)
+	if err != nil || !accountExists {
+		return nil, accountExists, err
 	}
 
 	fileClient := storageClient.GetFileService()
 	return &fileClient, true, nil
 }
 
-func (armClient *ArmClient) getTableServiceClientForStorageAccount(resourceGroupName, storageAccountName string) (*mainStorage.TableServiceClient, bool, error) {
-	key, accountExists, err := armClient.getKeyForStorageAccount(resourceGroupName, storageAccountName)
-	if err != nil {
+func (armClient *ArmClient) getTableServiceClientForStorageAccount(resourceGroupName, storageAccountName, accountKey string) (*mainStorage.TableServiceClient, bool, error) {
+	storageClient, accountExists, err := armClient.getStorageClientForStorageAccount(resourceGroupName, storageAccountName, accountKey)
+	if err != nil || !accountExists {
 		return nil, accountExists, err
 	}
-	if accountExists == false {
-		return nil, false, nil
-	}
-
-	storageClient, err := mainStorage.NewClient(storageAccountName, key, armClient.environment.StorageEndpointSuffix,
-		mainStorage.DefaultAPIVersion, true)
-	if err != nil {
-		return nil, true, fmt.Errorf("Error creating storage client for storage account %q: %s", storageAccountName, err)
-	}
 
 	tableClient := storageClient.GetTableService()
 	return &tableClient, true, nil
 }
 
-func (armClient *ArmClient) getQueueServiceClientForStorageAccount(resourceGroupName, storageAccountName string) (*mainStorage.QueueServiceClient, bool, error) {
-	key, accountExists, err := armClient.getKeyForStorageAccount(resourceGroupName, storageAccountName)
-	if err != nil {
+func (armClient *ArmClient) getQueueServiceClientForStorageAccount(resourceGroupName, storageAccountName, accountKey string) (*mainStorage.QueueServiceClient, bool, error) {
+	storageClient, accountExists, err := armClient.getStorageClientForStorageAccount(resourceGroupName, storageAccountName, accountKey)
+	if err != nil || !accountExists {
 		return nil, accountExists, err
 	}
-	if accountExists == false {
-		return nil, false, nil
-	}
-
-	storageClient, err := mainStorage.NewClient(storageAccountName, key, armClient.environment.StorageEndpointSuffix,
-		mainStorage.DefaultAPIVersion, true)
-	if err != nil {
-		return nil, true, fmt.Errorf("Error creating storage client for storage account %q: %s", storageAccountName, err)
-	}
 
 	queueClient := storageClient.GetQueueService()
 	return &queueClient, true, nil
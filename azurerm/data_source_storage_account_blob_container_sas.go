@@ -0,0 +1,292 @@
+package azurerm
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/hashcode"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// the version of the Storage Service used to sign the Shared Access Signature
+const storageContainerSasSignedVersion = "2017-04-17"
+
+func dataSourceArmStorageAccountBlobContainerSas() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmStorageAccountBlobContainerSasRead,
+
+		Schema: map[string]*schema.Schema{
+			"connection_string": {
+				Type:      schema.TypeString,
+				Required:  true,
+				Sensitive: true,
+			},
+
+			"container_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			"https_only": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+
+			"ip_address": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			// the `start`, `expiry` and `permissions` can instead be defined in a Stored Access Policy
+			"access_policy_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"start": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRFC3339Date,
+			},
+
+			"expiry": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRFC3339Date,
+			},
+
+			"permissions": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"read": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"add": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"create": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"write": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"delete": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"list": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+					},
+				},
+			},
+
+			"sas": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+		},
+	}
+}
+
+func dataSourceArmStorageAccountBlobContainerSasRead(d *schema.ResourceData, meta interface{}) error {
+	connectionString := d.Get("connection_string").(string)
+	containerName := d.Get("container_name").(string)
+	httpsOnly := d.Get("https_only").(bool)
+	ipAddress := d.Get("ip_address").(string)
+	accessPolicyId := d.Get("access_policy_id").(string)
+	start := d.Get("start").(string)
+	expiry := d.Get("expiry").(string)
+	permissionsList := d.Get("permissions").([]interface{})
+
+	if accessPolicyId == "" && (start == "" || expiry == "" || len(permissionsList) == 0) {
+		return fmt.Errorf("`start`, `expiry` and `permissions` must be specified when an `access_policy_id` isn't")
+	}
+
+	var startTime, expiryTime time.Time
+	if start != "" {
+		t, err := time.Parse(time.RFC3339, start)
+		if err != nil {
+			return fmt.Errorf("Error parsing `start` %q: %+v", start, err)
+		}
+		startTime = t.UTC()
+	}
+
+	if expiry != "" {
+		t, err := time.Parse(time.RFC3339, expiry)
+		if err != nil {
+			return fmt.Errorf("Error parsing `expiry` %q: %+v", expiry, err)
+		}
+		expiryTime = t.UTC()
+	}
+
+	if start != "" && expiry != "" && !expiryTime.After(startTime) {
+		return fmt.Errorf("`expiry` (%q) must be after `start` (%q)", expiry, start)
+	}
+
+	connection, err := parseAzureStorageAccountConnectionString(connectionString)
+	if err != nil {
+		return err
+	}
+
+	accountName := connection["AccountName"]
+	accountKey := connection["AccountKey"]
+	if accountName == "" || accountKey == "" {
+		return fmt.Errorf("The `connection_string` must contain both an `AccountName` and an `AccountKey`")
+	}
+
+	permissions := ""
+	if len(permissionsList) > 0 && permissionsList[0] != nil {
+		permissions = buildStorageContainerSasPermissions(permissionsList[0].(map[string]interface{}))
+	}
+
+	protocols := "https,http"
+	if httpsOnly {
+		protocols = "https"
+	}
+
+	signedStart := ""
+	if start != "" {
+		signedStart = startTime.Format(time.RFC3339)
+	}
+
+	signedExpiry := ""
+	if expiry != "" {
+		signedExpiry = expiryTime.Format(time.RFC3339)
+	}
+
+	sasToken, err := computeStorageContainerSasToken(accountName, accountKey, containerName, accessPolicyId, permissions,
+		signedStart, signedExpiry, ipAddress, protocols)
+	if err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%d", hashcode.String(sasToken)))
+	d.Set("sas", sasToken)
+
+	return nil
+}
+
+// parseAzureStorageAccountConnectionString parses a Storage Account Connection String
+// (e.g. `AccountName=example;AccountKey=...`) into a map of its components
+func parseAzureStorageAccountConnectionString(connectionString string) (map[string]string, error) {
+	components := make(map[string]string)
+
+	for _, segment := range strings.Split(connectionString, ";") {
+		if segment == "" {
+			continue
+		}
+
+		// the Account Key is base64 encoded, so may contain `=`
+		kv := strings.SplitN(segment, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("Error parsing Connection String: expected a `key=value` pair but got %q", segment)
+		}
+
+		components[kv[0]] = kv[1]
+	}
+
+	return components, nil
+}
+
+// buildStorageContainerSasPermissions returns the Signed Permissions for a Container SAS,
+// which must be specified in the order `racwdl`
+func buildStorageContainerSasPermissions(input map[string]interface{}) string {
+	permissions := ""
+
+	ordered := []struct {
+		key   string
+		value string
+	}{
+		{"read", "r"},
+		{"add", "a"},
+		{"create", "c"},
+		{"write", "w"},
+		{"delete", "d"},
+		{"list", "l"},
+	}
+
+	for _, permission := range ordered {
+		if input[permission.key].(bool) {
+			permissions += permission.value
+		}
+	}
+
+	return permissions
+}
+
+// computeStorageContainerSasToken computes a Service SAS Token for the specified Container,
+// signed using the Storage Account Key. The identifier, permissions, start and expiry are
+// optional, since these can be taken from a Stored Access Policy.
+// See https://docs.microsoft.com/en-us/rest/api/storageservices/constructing-a-service-sas
+func computeStorageContainerSasToken(accountName, accountKey, containerName, identifier, permissions, start, expiry, ipAddress, protocols string) (string, error) {
+	key, err := base64.StdEncoding.DecodeString(accountKey)
+	if err != nil {
+		return "", fmt.Errorf("Error decoding the Storage Account Key: %+v", err)
+	}
+
+	canonicalizedResource := fmt.Sprintf("/blob/%s/%s", accountName, containerName)
+
+	// the Response Header overrides (rscc, rscd, rsce, rscl & rsct) aren't supported here, so are left empty
+	stringToSign := strings.Join([]string{
+		permissions,
+		start,
+		expiry,
+		canonicalizedResource,
+		identifier,
+		ipAddress,
+		protocols,
+		storageContainerSasSignedVersion,
+		"",
+		"",
+		"",
+		"",
+		"",
+	}, "\n")
+
+	hasher := hmac.New(sha256.New, key)
+	hasher.Write([]byte(stringToSign))
+	signature := base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+
+	params := url.Values{
+		"sv":  {storageContainerSasSignedVersion},
+		"sr":  {"c"},
+		"spr": {protocols},
+		"sig": {signature},
+	}
+
+	optionalParams := map[string]string{
+		"si":  identifier,
+		"st":  start,
+		"se":  expiry,
+		"sp":  permissions,
+		"sip": ipAddress,
+	}
+	for key, value := range optionalParams {
+		if value != "" {
+			params.Add(key, value)
+		}
+	}
+
+	return fmt.Sprintf("?%s", params.Encode()), nil
+}
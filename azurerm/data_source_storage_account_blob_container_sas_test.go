@@ -0,0 +1,155 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestParseAzureStorageAccountConnectionString(t *testing.T) {
+	input := "DefaultEndpointsProtocol=https;AccountName=example;AccountKey=bm90LWEtcmVhbC1rZXk=;EndpointSuffix=core.windows.net"
+	expected := map[string]string{
+		"DefaultEndpointsProtocol": "https",
+		"AccountName":              "example",
+		"AccountKey":               "bm90LWEtcmVhbC1rZXk=",
+		"EndpointSuffix":           "core.windows.net",
+	}
+
+	actual, err := parseAzureStorageAccountConnectionString(input)
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	for k, v := range expected {
+		if actual[k] != v {
+			t.Fatalf("Expected %q to be %q but got %q", k, v, actual[k])
+		}
+	}
+
+	if _, err := parseAzureStorageAccountConnectionString("AccountName=example;invalid"); err == nil {
+		t.Fatalf("Expected an error parsing an invalid Connection String but didn't get one")
+	}
+}
+
+func TestBuildStorageContainerSasPermissions(t *testing.T) {
+	testCases := []struct {
+		read     bool
+		add      bool
+		create   bool
+		write    bool
+		delete   bool
+		list     bool
+		expected string
+	}{
+		{true, true, true, true, true, true, "racwdl"},
+		{true, false, false, false, false, true, "rl"},
+		{false, false, true, true, false, false, "cw"},
+		{false, false, false, false, false, false, ""},
+	}
+
+	for _, test := range testCases {
+		input := map[string]interface{}{
+			"read":   test.read,
+			"add":    test.add,
+			"create": test.create,
+			"write":  test.write,
+			"delete": test.delete,
+			"list":   test.list,
+		}
+
+		if actual := buildStorageContainerSasPermissions(input); actual != test.expected {
+			t.Fatalf("Expected the permissions to be %q but got %q", test.expected, actual)
+		}
+	}
+}
+
+func TestComputeStorageContainerSasToken(t *testing.T) {
+	expected := "?se=2018-03-22T00%3A00%3A00Z&sig=OlQFibbVo6sp3I%2BZvjzEoVTFs5KtuMtV6rCISUEdJn4%3D&sp=rl&spr=https&sr=c&st=2018-03-21T00%3A00%3A00Z&sv=2017-04-17"
+
+	actual, err := computeStorageContainerSasToken("example", "bm90LWEtcmVhbC1rZXk=", "images", "", "rl", "2018-03-21T00:00:00Z", "2018-03-22T00:00:00Z", "", "https")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	if actual != expected {
+		t.Fatalf("Expected the SAS Token to be %q but got %q", expected, actual)
+	}
+}
+
+func TestComputeStorageContainerSasToken_accessPolicy(t *testing.T) {
+	expected := "?si=read-only&sig=%2BvWjb3odVPzFZx7Bj2kbunTNResvSgDYoQc32QPrRos%3D&spr=https&sr=c&sv=2017-04-17"
+
+	actual, err := computeStorageContainerSasToken("example", "bm90LWEtcmVhbC1rZXk=", "images", "read-only", "", "", "", "", "https")
+	if err != nil {
+		t.Fatalf("Expected no error but got: %+v", err)
+	}
+
+	if actual != expected {
+		t.Fatalf("Expected the SAS Token to be %q but got %q", expected, actual)
+	}
+}
+
+func TestAccDataSourceAzureRMStorageAccountBlobContainerSas_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_storage_account_blob_container_sas.test"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	config := testAccDataSourceAzureRMStorageAccountBlobContainerSas_basic(ri, rs, testLocation())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet(dataSourceName, "sas"),
+					resource.TestCheckResourceAttr(dataSourceName, "https_only", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "start", "2018-03-21T00:00:00Z"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMStorageAccountBlobContainerSas_basic(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestrg-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+  name                     = "acctestsa%s"
+  resource_group_name      = "${azurerm_resource_group.test.name}"
+  location                 = "${azurerm_resource_group.test.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  name                  = "sas-test"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  storage_account_name  = "${azurerm_storage_account.test.name}"
+  container_access_type = "private"
+}
+
+data "azurerm_storage_account_blob_container_sas" "test" {
+  connection_string = "${azurerm_storage_account.test.primary_connection_string}"
+  container_name    = "${azurerm_storage_container.test.name}"
+  https_only        = true
+  start             = "2018-03-21T00:00:00Z"
+  expiry            = "2018-03-22T00:00:00Z"
+
+  permissions {
+    read   = true
+    add    = true
+    create = false
+    write  = false
+    delete = true
+    list   = true
+  }
+}
+`, rInt, location, rString)
+}
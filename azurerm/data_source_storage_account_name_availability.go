@@ -0,0 +1,76 @@
+package azurerm
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/arm/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func dataSourceArmStorageAccountNameAvailability() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmStorageAccountNameAvailabilityRead,
+		Schema: map[string]*schema.Schema{
+			// NOTE: this intentionally isn't validated, so that the reason an invalid name can't be used is returned
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Computed
+			"available": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+
+			"reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"message": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceArmStorageAccountNameAvailabilityRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).storageServiceClient
+
+	name := d.Get("name").(string)
+	parameters := storage.AccountCheckNameAvailabilityParameters{
+		Name: utils.String(name),
+		Type: utils.String("Microsoft.Storage/storageAccounts"),
+	}
+
+	var resp storage.CheckNameAvailabilityResult
+	err := retryOnThrottling(func() (autorest.Response, error) {
+		var err error
+		resp, err = client.CheckNameAvailability(parameters)
+		return resp.Response, err
+	})
+	if err != nil {
+		return fmt.Errorf("Error checking the availability of the Storage Account name %q: %+v", name, err)
+	}
+
+	d.SetId(name)
+
+	available := false
+	if resp.NameAvailable != nil {
+		available = *resp.NameAvailable
+	}
+	d.Set("available", available)
+	d.Set("reason", string(resp.Reason))
+
+	message := ""
+	if resp.Message != nil {
+		message = *resp.Message
+	}
+	d.Set("message", message)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func TestAccDataSourceAzureRMStorageAccountNameAvailability_available(t *testing.T) {
+	dataSourceName := "data.azurerm_storage_account_name_availability.test"
+	rs := acctest.RandString(4)
+	config := testAccDataSourceAzureRMStorageAccountNameAvailability(fmt.Sprintf("unlikely23exst2acct%s", rs))
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "available", "true"),
+					resource.TestCheckResourceAttr(dataSourceName, "reason", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceAzureRMStorageAccountNameAvailability_invalid(t *testing.T) {
+	dataSourceName := "data.azurerm_storage_account_name_availability.test"
+	config := testAccDataSourceAzureRMStorageAccountNameAvailability("Invalid-Name")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "available", "false"),
+					resource.TestCheckResourceAttr(dataSourceName, "reason", "AccountNameInvalid"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "message"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMStorageAccountNameAvailability(name string) string {
+	return fmt.Sprintf(`
+data "azurerm_storage_account_name_availability" "test" {
+  name = "%s"
+}
+`, name)
+}
@@ -0,0 +1,132 @@
+package azurerm
+
+import (
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/arm/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceArmStorageAccounts() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceArmStorageAccountsRead,
+		Schema: map[string]*schema.Schema{
+			"resource_group_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+
+			// Computed
+			"storage_accounts": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"location": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"account_kind": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"account_tier": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"account_replication_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+
+						"primary_blob_endpoint": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceArmStorageAccountsRead(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+	client := armClient.storageServiceClient
+
+	resourceGroup := d.Get("resource_group_name").(string)
+
+	// NOTE: the 2016-12-01 API returns every Storage Account in the Resource Group in a single page
+	var resp storage.AccountListResult
+	err := retryOnThrottling(func() (autorest.Response, error) {
+		var err error
+		resp, err = client.ListByResourceGroup(resourceGroup)
+		return resp.Response, err
+	})
+	if err != nil {
+		return fmt.Errorf("Error listing Storage Accounts (Resource Group %q): %+v", resourceGroup, err)
+	}
+
+	d.SetId(fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.Storage/storageAccounts", armClient.subscriptionId, resourceGroup))
+
+	if err := d.Set("storage_accounts", flattenStorageAccountsList(resp.Value)); err != nil {
+		return fmt.Errorf("Error flattening `storage_accounts`: %+v", err)
+	}
+
+	return nil
+}
+
+func flattenStorageAccountsList(input *[]storage.Account) []interface{} {
+	output := make([]interface{}, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, account := range *input {
+		result := map[string]interface{}{
+			"account_kind": string(account.Kind),
+		}
+
+		if account.ID != nil {
+			result["id"] = *account.ID
+		}
+
+		if account.Name != nil {
+			result["name"] = *account.Name
+		}
+
+		if account.Location != nil {
+			result["location"] = azureRMNormalizeLocation(*account.Location)
+		}
+
+		if sku := account.Sku; sku != nil {
+			result["account_tier"] = string(sku.Tier)
+
+			if replicationType, err := parseStorageAccountReplicationType(string(sku.Name)); err == nil {
+				result["account_replication_type"] = replicationType
+			}
+		}
+
+		if props := account.AccountProperties; props != nil && props.PrimaryEndpoints != nil && props.PrimaryEndpoints.Blob != nil {
+			result["primary_blob_endpoint"] = *props.PrimaryEndpoints.Blob
+		}
+
+		output = append(output, result)
+	}
+
+	return output
+}
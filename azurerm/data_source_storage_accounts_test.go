@@ -0,0 +1,109 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/arm/storage"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestFlattenStorageAccountsList(t *testing.T) {
+	if output := flattenStorageAccountsList(nil); len(output) != 0 {
+		t.Fatalf("Expected no Storage Accounts but got %d", len(output))
+	}
+
+	input := []storage.Account{
+		{
+			ID:       utils.String("/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Storage/storageAccounts/examplestorage"),
+			Name:     utils.String("examplestorage"),
+			Location: utils.String("West Europe"),
+			Kind:     storage.BlobStorage,
+			Sku: &storage.Sku{
+				Name: storage.StandardRAGRS,
+				Tier: storage.Standard,
+			},
+			AccountProperties: &storage.AccountProperties{
+				PrimaryEndpoints: &storage.Endpoints{
+					Blob: utils.String("https://examplestorage.blob.core.windows.net/"),
+				},
+			},
+		},
+		{
+			Name: utils.String("emptystorage"),
+		},
+	}
+
+	output := flattenStorageAccountsList(&input)
+	if len(output) != 2 {
+		t.Fatalf("Expected 2 Storage Accounts but got %d", len(output))
+	}
+
+	account := output[0].(map[string]interface{})
+	expected := map[string]string{
+		"name":                     "examplestorage",
+		"location":                 "westeurope",
+		"account_kind":             "BlobStorage",
+		"account_tier":             "Standard",
+		"account_replication_type": "RAGRS",
+		"primary_blob_endpoint":    "https://examplestorage.blob.core.windows.net/",
+	}
+	for k, v := range expected {
+		if account[k] != v {
+			t.Fatalf("Expected %q to be %q but got %q", k, v, account[k])
+		}
+	}
+
+	empty := output[1].(map[string]interface{})
+	if _, ok := empty["primary_blob_endpoint"]; ok {
+		t.Fatalf("Expected no `primary_blob_endpoint` when no endpoints are returned")
+	}
+}
+
+func TestAccDataSourceAzureRMStorageAccounts_basic(t *testing.T) {
+	dataSourceName := "data.azurerm_storage_accounts.test"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	config := testAccDataSourceAzureRMStorageAccounts_basic(ri, rs, testLocation())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(dataSourceName, "storage_accounts.#", "1"),
+					resource.TestCheckResourceAttr(dataSourceName, "storage_accounts.0.name", fmt.Sprintf("unlikely23exst2acct%s", rs)),
+					resource.TestCheckResourceAttr(dataSourceName, "storage_accounts.0.account_kind", "Storage"),
+					resource.TestCheckResourceAttr(dataSourceName, "storage_accounts.0.account_tier", "Standard"),
+					resource.TestCheckResourceAttr(dataSourceName, "storage_accounts.0.account_replication_type", "GRS"),
+					resource.TestCheckResourceAttrSet(dataSourceName, "storage_accounts.0.primary_blob_endpoint"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceAzureRMStorageAccounts_basic(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+  name     = "testAccAzureRMSA-%d"
+  location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+  name                     = "unlikely23exst2acct%s"
+  resource_group_name      = "${azurerm_resource_group.testrg.name}"
+  location                 = "${azurerm_resource_group.testrg.location}"
+  account_tier             = "Standard"
+  account_replication_type = "GRS"
+}
+
+data "azurerm_storage_accounts" "test" {
+  resource_group_name = "${azurerm_storage_account.testsa.resource_group_name}"
+}
+`, rInt, location, rString)
+}
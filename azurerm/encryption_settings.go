@@ -1,6 +1,10 @@
 package azurerm
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
+
 	"github.com/Azure/azure-sdk-for-go/arm/disk"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
@@ -29,13 +33,15 @@ func encryptionSettingsSchema() *schema.Schema {
 					Elem: &schema.Resource{
 						Schema: map[string]*schema.Schema{
 							"secret_url": {
-								Type:     schema.TypeString,
-								Required: true,
+								Type:             schema.TypeString,
+								Required:         true,
+								DiffSuppressFunc: keyVaultURLDiffSuppressFunc,
 							},
 
 							"source_vault_id": {
-								Type:     schema.TypeString,
-								Required: true,
+								Type:             schema.TypeString,
+								Required:         true,
+								DiffSuppressFunc: keyVaultIDDiffSuppressFunc,
 							},
 						},
 					},
@@ -47,13 +53,15 @@ func encryptionSettingsSchema() *schema.Schema {
 					Elem: &schema.Resource{
 						Schema: map[string]*schema.Schema{
 							"key_url": {
-								Type:     schema.TypeString,
-								Required: true,
+								Type:             schema.TypeString,
+								Required:         true,
+								DiffSuppressFunc: keyVaultURLDiffSuppressFunc,
 							},
 
 							"source_vault_id": {
-								Type:     schema.TypeString,
-								Required: true,
+								Type:             schema.TypeString,
+								Required:         true,
+								DiffSuppressFunc: keyVaultIDDiffSuppressFunc,
 							},
 						},
 					},
@@ -106,9 +114,9 @@ func flattenManagedDiskEncryptionSettings(encryptionSettings *disk.EncryptionSet
 	if key := encryptionSettings.DiskEncryptionKey; key != nil {
 		keys := make(map[string]interface{}, 0)
 
-		keys["secret_url"] = *key.SecretURL
-		if vault := key.SourceVault; vault != nil {
-			keys["source_vault_id"] = *vault.ID
+		keys["secret_url"] = normalizeKeyVaultURL(*key.SecretURL)
+		if vault := key.SourceVault; vault != nil && vault.ID != nil {
+			keys["source_vault_id"] = normalizeKeyVaultID(*vault.ID)
 		}
 
 		value["disk_encryption_key"] = []interface{}{keys}
@@ -117,10 +125,10 @@ func flattenManagedDiskEncryptionSettings(encryptionSettings *disk.EncryptionSet
 	if key := encryptionSettings.KeyEncryptionKey; key != nil {
 		keys := make(map[string]interface{}, 0)
 
-		keys["key_url"] = *key.KeyURL
+		keys["key_url"] = normalizeKeyVaultURL(*key.KeyURL)
 
-		if vault := key.SourceVault; key != nil {
-			keys["source_vault_id"] = *vault.ID
+		if vault := key.SourceVault; vault != nil && vault.ID != nil {
+			keys["source_vault_id"] = normalizeKeyVaultID(*vault.ID)
 		}
 
 		value["key_encryption_key"] = []interface{}{keys}
@@ -130,3 +138,42 @@ func flattenManagedDiskEncryptionSettings(encryptionSettings *disk.EncryptionSet
 	output = append(output, value)
 	return output
 }
+
+// normalizeKeyVaultURL returns the URL of a Key Vault Secret/Key with a lower-cased scheme and host and
+// without a trailing slash, since the API doesn't always return these in the format they were specified.
+func normalizeKeyVaultURL(input string) string {
+	uri, err := url.Parse(input)
+	if err != nil || uri.Host == "" {
+		return input
+	}
+
+	uri.Scheme = strings.ToLower(uri.Scheme)
+	uri.Host = strings.ToLower(uri.Host)
+	uri.Path = strings.TrimSuffix(uri.Path, "/")
+	return uri.String()
+}
+
+// normalizeKeyVaultID returns the ID of a Key Vault in its canonical format, since the casing of
+// the segments (e.g. `resourcegroups` / `microsoft.keyvault`) returned from the API can differ.
+func normalizeKeyVaultID(input string) string {
+	id, err := parseAzureResourceID(input)
+	if err != nil {
+		return input
+	}
+
+	name, err := getAzureResourceIDPathValue(id, "vaults")
+	if err != nil {
+		return input
+	}
+
+	return fmt.Sprintf("/subscriptions/%s/resourceGroups/%s/providers/Microsoft.KeyVault/vaults/%s", id.SubscriptionID, id.ResourceGroup, name)
+}
+
+// Key Vault names (and the names of Secrets/Keys within them) are case-insensitive
+func keyVaultURLDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(normalizeKeyVaultURL(old), normalizeKeyVaultURL(new))
+}
+
+func keyVaultIDDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(normalizeKeyVaultID(old), normalizeKeyVaultID(new))
+}
@@ -0,0 +1,73 @@
+package azurerm
+
+import "testing"
+
+func TestNormalizeKeyVaultURL(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "https://example-vault.vault.azure.net/secrets/example/00000000000000000000000000000000",
+			expected: "https://example-vault.vault.azure.net/secrets/example/00000000000000000000000000000000",
+		},
+		{
+			input:    "HTTPS://Example-Vault.Vault.Azure.NET/secrets/example/00000000000000000000000000000000/",
+			expected: "https://example-vault.vault.azure.net/secrets/example/00000000000000000000000000000000",
+		},
+		{
+			input:    "not-a-url",
+			expected: "not-a-url",
+		},
+	}
+
+	for _, test := range testCases {
+		if actual := normalizeKeyVaultURL(test.input); actual != test.expected {
+			t.Fatalf("Expected %q to be normalized to %q but got %q", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestNormalizeKeyVaultID(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{
+			input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.KeyVault/vaults/example-vault",
+			expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.KeyVault/vaults/example-vault",
+		},
+		{
+			input:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourcegroups/example-resources/providers/microsoft.keyvault/Vaults/example-vault/",
+			expected: "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.KeyVault/vaults/example-vault",
+		},
+		{
+			input:    "example-vault",
+			expected: "example-vault",
+		},
+	}
+
+	for _, test := range testCases {
+		if actual := normalizeKeyVaultID(test.input); actual != test.expected {
+			t.Fatalf("Expected %q to be normalized to %q but got %q", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestKeyVaultURLDiffSuppressFunc(t *testing.T) {
+	testCases := []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{"https://example-vault.vault.azure.net/secrets/example/1", "https://Example-Vault.vault.azure.net/secrets/Example/1/", true},
+		{"https://example-vault.vault.azure.net/secrets/example/1", "https://example-vault.vault.azure.net/secrets/example/2", false},
+		{"https://example-vault.vault.azure.net/secrets/example/1", "https://other-vault.vault.azure.net/secrets/example/1", false},
+	}
+
+	for _, test := range testCases {
+		if actual := keyVaultURLDiffSuppressFunc("secret_url", test.old, test.new, nil); actual != test.suppress {
+			t.Fatalf("Expected the diff between %q and %q to be suppressed %t but got %t", test.old, test.new, test.suppress, actual)
+		}
+	}
+}
@@ -51,3 +51,30 @@ func TestAccAzureRMSnapshot_importEncryption(t *testing.T) {
 		},
 	})
 }
+
+func TestAccAzureRMSnapshot_importLowerCaseCreateOption(t *testing.T) {
+	resourceName := "azurerm_snapshot.test"
+	ri := acctest.RandInt()
+	config := testAccAzureRMSnapshot_lowerCaseCreateOption(ri, testLocation())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+			},
+			{
+				ResourceName:      resourceName,
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// the API returns `Copy`, which shouldn't cause a diff
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
@@ -65,109 +65,121 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("ARM_SKIP_PROVIDER_REGISTRATION", false),
 			},
+
+			"enforce_storage_https_traffic_only": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("ARM_ENFORCE_STORAGE_HTTPS_TRAFFIC_ONLY", false),
+			},
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"azurerm_builtin_role_definition": dataSourceArmBuiltInRoleDefinition(),
-			"azurerm_client_config":           dataSourceArmClientConfig(),
-			"azurerm_image":                   dataSourceArmImage(),
-			"azurerm_managed_disk":            dataSourceArmManagedDisk(),
-			"azurerm_platform_image":          dataSourceArmPlatformImage(),
-			"azurerm_public_ip":               dataSourceArmPublicIP(),
-			"azurerm_resource_group":          dataSourceArmResourceGroup(),
-			"azurerm_role_definition":         dataSourceArmRoleDefinition(),
-			"azurerm_snapshot":                dataSourceArmSnapshot(),
-			"azurerm_subnet":                  dataSourceArmSubnet(),
-			"azurerm_subscription":            dataSourceArmSubscription(),
+			"azurerm_builtin_role_definition":            dataSourceArmBuiltInRoleDefinition(),
+			"azurerm_client_config":                      dataSourceArmClientConfig(),
+			"azurerm_image":                              dataSourceArmImage(),
+			"azurerm_managed_disk":                       dataSourceArmManagedDisk(),
+			"azurerm_platform_image":                     dataSourceArmPlatformImage(),
+			"azurerm_public_ip":                          dataSourceArmPublicIP(),
+			"azurerm_resource_group":                     dataSourceArmResourceGroup(),
+			"azurerm_role_definition":                    dataSourceArmRoleDefinition(),
+			"azurerm_snapshot":                           dataSourceArmSnapshot(),
+			"azurerm_storage_account_blob_container_sas": dataSourceArmStorageAccountBlobContainerSas(),
+			"azurerm_storage_account_name_availability":  dataSourceArmStorageAccountNameAvailability(),
+			"azurerm_storage_accounts":                   dataSourceArmStorageAccounts(),
+			"azurerm_subnet":                             dataSourceArmSubnet(),
+			"azurerm_subscription":                       dataSourceArmSubscription(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"azurerm_application_insights":        resourceArmApplicationInsights(),
-			"azurerm_app_service":                 resourceArmAppService(),
-			"azurerm_app_service_plan":            resourceArmAppServicePlan(),
-			"azurerm_automation_account":          resourceArmAutomationAccount(),
-			"azurerm_automation_credential":       resourceArmAutomationCredential(),
-			"azurerm_automation_runbook":          resourceArmAutomationRunbook(),
-			"azurerm_automation_schedule":         resourceArmAutomationSchedule(),
-			"azurerm_availability_set":            resourceArmAvailabilitySet(),
-			"azurerm_cdn_endpoint":                resourceArmCdnEndpoint(),
-			"azurerm_cdn_profile":                 resourceArmCdnProfile(),
-			"azurerm_container_registry":          resourceArmContainerRegistry(),
-			"azurerm_container_service":           resourceArmContainerService(),
-			"azurerm_container_group":             resourceArmContainerGroup(),
-			"azurerm_cosmosdb_account":            resourceArmCosmosDBAccount(),
-			"azurerm_dns_a_record":                resourceArmDnsARecord(),
-			"azurerm_dns_aaaa_record":             resourceArmDnsAAAARecord(),
-			"azurerm_dns_cname_record":            resourceArmDnsCNameRecord(),
-			"azurerm_dns_mx_record":               resourceArmDnsMxRecord(),
-			"azurerm_dns_ns_record":               resourceArmDnsNsRecord(),
-			"azurerm_dns_ptr_record":              resourceArmDnsPtrRecord(),
-			"azurerm_dns_srv_record":              resourceArmDnsSrvRecord(),
-			"azurerm_dns_txt_record":              resourceArmDnsTxtRecord(),
-			"azurerm_dns_zone":                    resourceArmDnsZone(),
-			"azurerm_eventgrid_topic":             resourceArmEventGridTopic(),
-			"azurerm_eventhub":                    resourceArmEventHub(),
-			"azurerm_eventhub_authorization_rule": resourceArmEventHubAuthorizationRule(),
-			"azurerm_eventhub_consumer_group":     resourceArmEventHubConsumerGroup(),
-			"azurerm_eventhub_namespace":          resourceArmEventHubNamespace(),
-			"azurerm_express_route_circuit":       resourceArmExpressRouteCircuit(),
-			"azurerm_image":                       resourceArmImage(),
-			"azurerm_key_vault":                   resourceArmKeyVault(),
-			"azurerm_key_vault_certificate":       resourceArmKeyVaultCertificate(),
-			"azurerm_key_vault_key":               resourceArmKeyVaultKey(),
-			"azurerm_key_vault_secret":            resourceArmKeyVaultSecret(),
-			"azurerm_lb":                          resourceArmLoadBalancer(),
-			"azurerm_lb_backend_address_pool":     resourceArmLoadBalancerBackendAddressPool(),
-			"azurerm_lb_nat_rule":                 resourceArmLoadBalancerNatRule(),
-			"azurerm_lb_nat_pool":                 resourceArmLoadBalancerNatPool(),
-			"azurerm_lb_probe":                    resourceArmLoadBalancerProbe(),
-			"azurerm_lb_rule":                     resourceArmLoadBalancerRule(),
-			"azurerm_local_network_gateway":       resourceArmLocalNetworkGateway(),
-			"azurerm_log_analytics_workspace":     resourceArmLogAnalyticsWorkspace(),
-			"azurerm_managed_disk":                resourceArmManagedDisk(),
-			"azurerm_mysql_configuration":         resourceArmMySQLConfiguration(),
-			"azurerm_mysql_database":              resourceArmMySqlDatabase(),
-			"azurerm_mysql_firewall_rule":         resourceArmMySqlFirewallRule(),
-			"azurerm_mysql_server":                resourceArmMySqlServer(),
-			"azurerm_network_interface":           resourceArmNetworkInterface(),
-			"azurerm_network_security_group":      resourceArmNetworkSecurityGroup(),
-			"azurerm_network_security_rule":       resourceArmNetworkSecurityRule(),
-			"azurerm_postgresql_configuration":    resourceArmPostgreSQLConfiguration(),
-			"azurerm_postgresql_database":         resourceArmPostgreSQLDatabase(),
-			"azurerm_postgresql_firewall_rule":    resourceArmPostgreSQLFirewallRule(),
-			"azurerm_postgresql_server":           resourceArmPostgreSQLServer(),
-			"azurerm_public_ip":                   resourceArmPublicIp(),
-			"azurerm_redis_cache":                 resourceArmRedisCache(),
-			"azurerm_resource_group":              resourceArmResourceGroup(),
-			"azurerm_role_assignment":             resourceArmRoleAssignment(),
-			"azurerm_role_definition":             resourceArmRoleDefinition(),
-			"azurerm_route":                       resourceArmRoute(),
-			"azurerm_route_table":                 resourceArmRouteTable(),
-			"azurerm_search_service":              resourceArmSearchService(),
-			"azurerm_servicebus_namespace":        resourceArmServiceBusNamespace(),
-			"azurerm_servicebus_queue":            resourceArmServiceBusQueue(),
-			"azurerm_servicebus_subscription":     resourceArmServiceBusSubscription(),
-			"azurerm_servicebus_topic":            resourceArmServiceBusTopic(),
-			"azurerm_snapshot":                    resourceArmSnapshot(),
-			"azurerm_sql_database":                resourceArmSqlDatabase(),
-			"azurerm_sql_elasticpool":             resourceArmSqlElasticPool(),
-			"azurerm_sql_firewall_rule":           resourceArmSqlFirewallRule(),
-			"azurerm_sql_server":                  resourceArmSqlServer(),
-			"azurerm_storage_account":             resourceArmStorageAccount(),
-			"azurerm_storage_blob":                resourceArmStorageBlob(),
-			"azurerm_storage_container":           resourceArmStorageContainer(),
-			"azurerm_storage_share":               resourceArmStorageShare(),
-			"azurerm_storage_queue":               resourceArmStorageQueue(),
-			"azurerm_storage_table":               resourceArmStorageTable(),
-			"azurerm_subnet":                      resourceArmSubnet(),
-			"azurerm_template_deployment":         resourceArmTemplateDeployment(),
-			"azurerm_traffic_manager_endpoint":    resourceArmTrafficManagerEndpoint(),
-			"azurerm_traffic_manager_profile":     resourceArmTrafficManagerProfile(),
-			"azurerm_virtual_machine_extension":   resourceArmVirtualMachineExtensions(),
-			"azurerm_virtual_machine":             resourceArmVirtualMachine(),
-			"azurerm_virtual_machine_scale_set":   resourceArmVirtualMachineScaleSet(),
-			"azurerm_virtual_network":             resourceArmVirtualNetwork(),
-			"azurerm_virtual_network_peering":     resourceArmVirtualNetworkPeering(),
+			"azurerm_application_insights":             resourceArmApplicationInsights(),
+			"azurerm_app_service":                      resourceArmAppService(),
+			"azurerm_app_service_plan":                 resourceArmAppServicePlan(),
+			"azurerm_automation_account":               resourceArmAutomationAccount(),
+			"azurerm_automation_credential":            resourceArmAutomationCredential(),
+			"azurerm_automation_runbook":               resourceArmAutomationRunbook(),
+			"azurerm_automation_schedule":              resourceArmAutomationSchedule(),
+			"azurerm_availability_set":                 resourceArmAvailabilitySet(),
+			"azurerm_cdn_endpoint":                     resourceArmCdnEndpoint(),
+			"azurerm_cdn_profile":                      resourceArmCdnProfile(),
+			"azurerm_container_registry":               resourceArmContainerRegistry(),
+			"azurerm_container_service":                resourceArmContainerService(),
+			"azurerm_container_group":                  resourceArmContainerGroup(),
+			"azurerm_cosmosdb_account":                 resourceArmCosmosDBAccount(),
+			"azurerm_dns_a_record":                     resourceArmDnsARecord(),
+			"azurerm_dns_aaaa_record":                  resourceArmDnsAAAARecord(),
+			"azurerm_dns_cname_record":                 resourceArmDnsCNameRecord(),
+			"azurerm_dns_mx_record":                    resourceArmDnsMxRecord(),
+			"azurerm_dns_ns_record":                    resourceArmDnsNsRecord(),
+			"azurerm_dns_ptr_record":                   resourceArmDnsPtrRecord(),
+			"azurerm_dns_srv_record":                   resourceArmDnsSrvRecord(),
+			"azurerm_dns_txt_record":                   resourceArmDnsTxtRecord(),
+			"azurerm_dns_zone":                         resourceArmDnsZone(),
+			"azurerm_eventgrid_topic":                  resourceArmEventGridTopic(),
+			"azurerm_eventhub":                         resourceArmEventHub(),
+			"azurerm_eventhub_authorization_rule":      resourceArmEventHubAuthorizationRule(),
+			"azurerm_eventhub_consumer_group":          resourceArmEventHubConsumerGroup(),
+			"azurerm_eventhub_namespace":               resourceArmEventHubNamespace(),
+			"azurerm_express_route_circuit":            resourceArmExpressRouteCircuit(),
+			"azurerm_image":                            resourceArmImage(),
+			"azurerm_key_vault":                        resourceArmKeyVault(),
+			"azurerm_key_vault_certificate":            resourceArmKeyVaultCertificate(),
+			"azurerm_key_vault_key":                    resourceArmKeyVaultKey(),
+			"azurerm_key_vault_secret":                 resourceArmKeyVaultSecret(),
+			"azurerm_lb":                               resourceArmLoadBalancer(),
+			"azurerm_lb_backend_address_pool":          resourceArmLoadBalancerBackendAddressPool(),
+			"azurerm_lb_nat_rule":                      resourceArmLoadBalancerNatRule(),
+			"azurerm_lb_nat_pool":                      resourceArmLoadBalancerNatPool(),
+			"azurerm_lb_probe":                         resourceArmLoadBalancerProbe(),
+			"azurerm_lb_rule":                          resourceArmLoadBalancerRule(),
+			"azurerm_local_network_gateway":            resourceArmLocalNetworkGateway(),
+			"azurerm_log_analytics_workspace":          resourceArmLogAnalyticsWorkspace(),
+			"azurerm_managed_disk":                     resourceArmManagedDisk(),
+			"azurerm_mysql_configuration":              resourceArmMySQLConfiguration(),
+			"azurerm_mysql_database":                   resourceArmMySqlDatabase(),
+			"azurerm_mysql_firewall_rule":              resourceArmMySqlFirewallRule(),
+			"azurerm_mysql_server":                     resourceArmMySqlServer(),
+			"azurerm_network_interface":                resourceArmNetworkInterface(),
+			"azurerm_network_security_group":           resourceArmNetworkSecurityGroup(),
+			"azurerm_network_security_rule":            resourceArmNetworkSecurityRule(),
+			"azurerm_postgresql_configuration":         resourceArmPostgreSQLConfiguration(),
+			"azurerm_postgresql_database":              resourceArmPostgreSQLDatabase(),
+			"azurerm_postgresql_firewall_rule":         resourceArmPostgreSQLFirewallRule(),
+			"azurerm_postgresql_server":                resourceArmPostgreSQLServer(),
+			"azurerm_public_ip":                        resourceArmPublicIp(),
+			"azurerm_redis_cache":                      resourceArmRedisCache(),
+			"azurerm_resource_group":                   resourceArmResourceGroup(),
+			"azurerm_role_assignment":                  resourceArmRoleAssignment(),
+			"azurerm_role_definition":                  resourceArmRoleDefinition(),
+			"azurerm_route":                            resourceArmRoute(),
+			"azurerm_route_table":                      resourceArmRouteTable(),
+			"azurerm_search_service":                   resourceArmSearchService(),
+			"azurerm_servicebus_namespace":             resourceArmServiceBusNamespace(),
+			"azurerm_servicebus_queue":                 resourceArmServiceBusQueue(),
+			"azurerm_servicebus_subscription":          resourceArmServiceBusSubscription(),
+			"azurerm_servicebus_topic":                 resourceArmServiceBusTopic(),
+			"azurerm_snapshot":                         resourceArmSnapshot(),
+			"azurerm_sql_database":                     resourceArmSqlDatabase(),
+			"azurerm_sql_elasticpool":                  resourceArmSqlElasticPool(),
+			"azurerm_sql_firewall_rule":                resourceArmSqlFirewallRule(),
+			"azurerm_sql_server":                       resourceArmSqlServer(),
+			"azurerm_storage_account":                  resourceArmStorageAccount(),
+			"azurerm_storage_account_blob_properties":  resourceArmStorageAccountBlobProperties(),
+			"azurerm_storage_account_queue_properties": resourceArmStorageAccountQueueProperties(),
+			"azurerm_storage_account_tag":              resourceArmStorageAccountTag(),
+			"azurerm_storage_blob":                     resourceArmStorageBlob(),
+			"azurerm_storage_container":                resourceArmStorageContainer(),
+			"azurerm_storage_share":                    resourceArmStorageShare(),
+			"azurerm_storage_queue":                    resourceArmStorageQueue(),
+			"azurerm_storage_table":                    resourceArmStorageTable(),
+			"azurerm_subnet":                           resourceArmSubnet(),
+			"azurerm_template_deployment":              resourceArmTemplateDeployment(),
+			"azurerm_traffic_manager_endpoint":         resourceArmTrafficManagerEndpoint(),
+			"azurerm_traffic_manager_profile":          resourceArmTrafficManagerProfile(),
+			"azurerm_virtual_machine_extension":        resourceArmVirtualMachineExtensions(),
+			"azurerm_virtual_machine":                  resourceArmVirtualMachine(),
+			"azurerm_virtual_machine_scale_set":        resourceArmVirtualMachineScaleSet(),
+			"azurerm_virtual_network":                  resourceArmVirtualNetwork(),
+			"azurerm_virtual_network_peering":          resourceArmVirtualNetworkPeering(),
 		},
 	}
 
@@ -189,6 +201,9 @@ type Config struct {
 	SkipCredentialsValidation bool
 	SkipProviderRegistration  bool
 
+	// Policy
+	EnforceStorageHTTPSTrafficOnly bool
+
 	// Service Principal Auth
 	ClientSecret string
 
@@ -339,6 +354,8 @@ func providerConfigure(p *schema.Provider) schema.ConfigureFunc {
 			Environment:               d.Get("environment").(string),
 			SkipCredentialsValidation: d.Get("skip_credentials_validation").(bool),
 			SkipProviderRegistration:  d.Get("skip_provider_registration").(bool),
+
+			EnforceStorageHTTPSTrafficOnly: d.Get("enforce_storage_https_traffic_only").(bool),
 		}
 
 		if config.ClientSecret != "" {
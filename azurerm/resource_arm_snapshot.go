@@ -3,14 +3,22 @@ package azurerm
 import (
 	"fmt"
 	"log"
+	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/Azure/azure-sdk-for-go/arm/disk"
+	"github.com/Azure/go-autorest/autorest"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
 	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
+// snapshotMaxDiskSizeGB is the largest Disk (in GB) which can be Snapshotted, matching the limit for Managed Disks
+const snapshotMaxDiskSizeGB = 4095
+
 func resourceArmSnapshot() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmSnapshotCreateUpdate,
@@ -44,32 +52,54 @@ func resourceArmSnapshot() *schema.Resource {
 			},
 
 			"source_uri": {
-				Type:     schema.TypeString,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validateSnapshotSourceURI,
 			},
 
 			"source_resource_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateSnapshotSourceResourceID,
 			},
 
 			"storage_account_id": {
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStorageAccountID,
 			},
 
 			"disk_size_gb": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				Computed: true,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.IntBetween(1, snapshotMaxDiskSizeGB),
 			},
 
 			"encryption_settings": encryptionSettingsSchema(),
 
-			"tags": tagsSchema(),
+			"copy_tags_from_source": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"copied_tags": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+			},
+
+			"tags": {
+				Type:             schema.TypeMap,
+				Optional:         true,
+				Computed:         true,
+				ValidateFunc:     validateAzureRMTags,
+				DiffSuppressFunc: snapshotCopiedTagsDiffSuppressFunc,
+			},
 		},
 	}
 }
@@ -81,7 +111,16 @@ func resourceArmSnapshotCreateUpdate(d *schema.ResourceData, meta interface{}) e
 	resourceGroup := d.Get("resource_group_name").(string)
 	location := azureRMNormalizeLocation(d.Get("location").(string))
 	createOption := d.Get("create_option").(string)
-	tags := d.Get("tags").(map[string]interface{})
+	tags := expandTags(d.Get("tags").(map[string]interface{}))
+
+	// the source is only used when the Snapshot is created, so existing Snapshots aren't re-validated when updated
+	if d.IsNewResource() {
+		sourceUri := d.Get("source_uri").(string)
+		storageAccountId := d.Get("storage_account_id").(string)
+		if err := validateSnapshotImportSource(sourceUri, storageAccountId); err != nil {
+			return err
+		}
+	}
 
 	properties := disk.Snapshot{
 		Location: utils.String(location),
@@ -90,7 +129,7 @@ func resourceArmSnapshotCreateUpdate(d *schema.ResourceData, meta interface{}) e
 				CreateOption: disk.CreateOption(createOption),
 			},
 		},
-		Tags: expandTags(tags),
+		Tags: tags,
 	}
 
 	if v, ok := d.GetOk("source_uri"); ok {
@@ -101,6 +140,28 @@ func resourceArmSnapshotCreateUpdate(d *schema.ResourceData, meta interface{}) e
 		properties.Properties.CreationData.SourceResourceID = utils.String(v.(string))
 	}
 
+	// the Tags are only copied from the source when the Snapshot is created (since the source may since have
+	// been deleted) - after which the copied Tags are retained from the State
+	copiedTagNames := make([]interface{}, 0)
+	if d.Get("copy_tags_from_source").(bool) {
+		var sourceTags *map[string]*string
+		if d.IsNewResource() {
+			if sourceResourceId := d.Get("source_resource_id").(string); sourceResourceId != "" {
+				var err error
+				sourceTags, err = retrieveSnapshotSourceTags(meta.(*ArmClient), sourceResourceId)
+				if err != nil {
+					return err
+				}
+			}
+		} else {
+			o, _ := d.GetChange("tags")
+			sourceTags = retainSnapshotCopiedTags(o.(map[string]interface{}), d.Get("copied_tags").([]interface{}))
+		}
+
+		properties.Tags = mergeSnapshotTags(sourceTags, tags)
+		copiedTagNames = flattenSnapshotCopiedTagNames(sourceTags, tags)
+	}
+
 	if v, ok := d.GetOk("storage_account_id"); ok {
 		properties.Properties.CreationData.StorageAccountID = utils.String(v.(string))
 	}
@@ -116,18 +177,29 @@ func resourceArmSnapshotCreateUpdate(d *schema.ResourceData, meta interface{}) e
 		properties.EncryptionSettings = expandManagedDiskEncryptionSettings(settings)
 	}
 
-	_, createErr := client.CreateOrUpdate(resourceGroup, name, properties, make(chan struct{}))
-	err := <-createErr
+	// taking a Snapshot can transiently return a 409 whilst another operation holds a lock on the source Disk
+	err := retryOnThrottlingOrConflict(func() (autorest.Response, error) {
+		respChan, errChan := client.CreateOrUpdate(resourceGroup, name, properties, make(chan struct{}))
+		resp := <-respChan
+		err := <-errChan
+		return resp.Response, err
+	})
 	if err != nil {
 		return err
 	}
 
-	resp, err := client.Get(resourceGroup, name)
+	var resp disk.Snapshot
+	err = retryOnThrottling(func() (autorest.Response, error) {
+		var err error
+		resp, err = client.Get(resourceGroup, name)
+		return resp.Response, err
+	})
 	if err != nil {
 		return err
 	}
 
 	d.SetId(*resp.ID)
+	d.Set("copied_tags", copiedTagNames)
 
 	return resourceArmSnapshotRead(d, meta)
 }
@@ -141,9 +213,17 @@ func resourceArmSnapshotRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	resourceGroup := id.ResourceGroup
-	name := id.Path["snapshots"]
+	name, err := getAzureResourceIDPathValue(id, "snapshots")
+	if err != nil {
+		return err
+	}
 
-	resp, err := client.Get(resourceGroup, name)
+	var resp disk.Snapshot
+	err = retryOnThrottling(func() (autorest.Response, error) {
+		var err error
+		resp, err = client.Get(resourceGroup, name)
+		return resp.Response, err
+	})
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
 			log.Printf("[INFO] Error reading Snapshot %q - removing from state", d.Id())
@@ -171,11 +251,14 @@ func resourceArmSnapshotRead(d *schema.ResourceData, meta interface{}) error {
 				d.Set("source_resource_id", data.SourceResourceID)
 			}
 
+			storageAccountId := ""
 			if data.StorageAccountID != nil {
-				d.Set("storage_account_id", *data.StorageAccountID)
+				storageAccountId = *data.StorageAccountID
 			}
+			d.Set("storage_account_id", storageAccountId)
 		}
 
+		// the size is always returned from the API, including when it's inherited from the source
 		if props.DiskSizeGB != nil {
 			d.Set("disk_size_gb", int(*props.DiskSizeGB))
 		}
@@ -185,6 +268,9 @@ func resourceArmSnapshotRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
+	// this isn't returned from the API, but needs to be set so that it's defaulted when importing
+	d.Set("copied_tags", d.Get("copied_tags").([]interface{}))
+
 	flattenAndSetTags(d, resp.Tags)
 
 	return nil
@@ -199,7 +285,10 @@ func resourceArmSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	resourceGroup := id.ResourceGroup
-	name := id.Path["snapshots"]
+	name, err := getAzureResourceIDPathValue(id, "snapshots")
+	if err != nil {
+		return err
+	}
 
 	deleteResp, deleteErr := client.Delete(resourceGroup, name, make(chan struct{}))
 	resp := <-deleteResp
@@ -235,3 +324,213 @@ func validateSnapshotName(v interface{}, k string) (ws []string, errors []error)
 
 	return
 }
+
+// validateSnapshotSourceURI ensures the `source_uri` is either the ID of a Managed Disk, or the URI of a
+// blob (in the format `https://{account}.blob.{suffix}/{container}/{blob}`) - since the API doesn't
+// support importing over HTTP, and would otherwise create a Snapshot which can't be used.
+func validateSnapshotSourceURI(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	if strings.HasPrefix(value, "/") {
+		if _, err := parseAzureResourceID(value); err != nil {
+			errors = append(errors, fmt.Errorf("Error parsing %q as a Resource ID: %+v", k, err))
+		}
+		return
+	}
+
+	uri, err := url.Parse(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("Error parsing %q as a URI: %+v", k, err))
+		return
+	}
+
+	if !strings.EqualFold(uri.Scheme, "https") {
+		errors = append(errors, fmt.Errorf("%q must use the `https` scheme: %q", k, value))
+	}
+
+	if uri.Host == "" {
+		errors = append(errors, fmt.Errorf("%q must contain the host name of the Storage Account: %q", k, value))
+	}
+
+	// the path must contain both the Container and the Blob
+	if segments := strings.Split(strings.Trim(uri.Path, "/"), "/"); len(segments) < 2 || segments[0] == "" {
+		errors = append(errors, fmt.Errorf("%q must be the URI of a Blob, in the format `https://{account}.blob.core.windows.net/{container}/{blob}`: %q", k, value))
+	}
+
+	return
+}
+
+// validateSnapshotSourceResourceID ensures the `source_resource_id` is the ID of a resource which can be
+// Snapshotted: a Managed Disk, another Snapshot or a Disk Restore Point (from a VM's Restore Point Collection).
+func validateSnapshotSourceResourceID(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	id, err := parseAzureResourceID(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("Error parsing %q as a Resource ID: %+v", k, err))
+		return
+	}
+
+	for _, key := range []string{"disks", "snapshots", "diskRestorePoints"} {
+		if name, err := getAzureResourceIDPathValue(id, key); err == nil && name != "" {
+			return
+		}
+	}
+
+	errors = append(errors, fmt.Errorf("%q must be the ID of a Managed Disk, Snapshot or Disk Restore Point: %q", k, value))
+	return
+}
+
+// validateSnapshotImportSource ensures that when a `storage_account_id` is specified (which is only needed
+// to authorize importing a blob from a Storage Account in another Subscription) the `source_uri` is a blob
+// within that Storage Account, since that's what the API authorizes against.
+func validateSnapshotImportSource(sourceUri string, storageAccountId string) error {
+	if storageAccountId == "" {
+		return nil
+	}
+
+	if sourceUri == "" {
+		return fmt.Errorf("`source_uri` must be specified when `storage_account_id` is specified")
+	}
+
+	id, err := parseAzureResourceID(storageAccountId)
+	if err != nil {
+		return fmt.Errorf("Error parsing `storage_account_id` %q: %+v", storageAccountId, err)
+	}
+	storageAccountName := id.Path["storageAccounts"]
+
+	uri, err := url.Parse(sourceUri)
+	if err != nil {
+		return fmt.Errorf("Error parsing `source_uri` %q: %+v", sourceUri, err)
+	}
+
+	// the host name is in the format `{account}.blob.{suffix}`
+	if accountName := strings.SplitN(uri.Host, ".", 2)[0]; !strings.EqualFold(accountName, storageAccountName) {
+		return fmt.Errorf("`source_uri` (%q) must be a blob within the Storage Account %q specified in `storage_account_id`", sourceUri, storageAccountName)
+	}
+
+	return nil
+}
+
+// retrieveSnapshotSourceTags returns the Tags assigned to the Managed Disk (or Snapshot) a Snapshot is taken from.
+func retrieveSnapshotSourceTags(client *ArmClient, sourceResourceId string) (*map[string]*string, error) {
+	id, err := parseAzureResourceID(sourceResourceId)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing `source_resource_id` %q: %+v", sourceResourceId, err)
+	}
+
+	if name, err := getAzureResourceIDPathValue(id, "disks"); err == nil {
+		resp, err := client.diskClient.Get(id.ResourceGroup, name)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving the source Managed Disk %q (Resource Group %q): %+v", name, id.ResourceGroup, err)
+		}
+		return resp.Tags, nil
+	}
+
+	if name, err := getAzureResourceIDPathValue(id, "snapshots"); err == nil {
+		resp, err := client.snapshotsClient.Get(id.ResourceGroup, name)
+		if err != nil {
+			return nil, fmt.Errorf("Error retrieving the source Snapshot %q (Resource Group %q): %+v", name, id.ResourceGroup, err)
+		}
+		return resp.Tags, nil
+	}
+
+	// Disk Restore Points (within a Restore Point Collection) don't have Tags of their own
+	if _, err := getAzureResourceIDPathValue(id, "diskRestorePoints"); err == nil {
+		log.Printf("[DEBUG] `source_resource_id` %q is a Disk Restore Point which has no Tags to copy", sourceResourceId)
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("`source_resource_id` must be the ID of a Managed Disk or Snapshot to copy its tags: %q", sourceResourceId)
+}
+
+// mergeSnapshotTags merges the Tags from the source into those specified by the user - where
+// the user-specified value wins if the same key is present in both.
+func mergeSnapshotTags(sourceTags *map[string]*string, userTags *map[string]*string) *map[string]*string {
+	output := make(map[string]*string)
+
+	if sourceTags != nil {
+		for k, v := range *sourceTags {
+			output[k] = v
+		}
+	}
+
+	if userTags != nil {
+		for k, v := range *userTags {
+			output[k] = v
+		}
+	}
+
+	return &output
+}
+
+// retainSnapshotCopiedTags returns the Tags which were copied from the source when the Snapshot was created,
+// so that they're retained when updating the Tags without needing to retrieve the source again.
+func retainSnapshotCopiedTags(existingTags map[string]interface{}, copiedTagNames []interface{}) *map[string]*string {
+	output := make(map[string]*string)
+
+	for _, name := range copiedTagNames {
+		if v, ok := existingTags[name.(string)]; ok {
+			output[name.(string)] = utils.String(v.(string))
+		}
+	}
+
+	return &output
+}
+
+// flattenSnapshotCopiedTagNames returns the (sorted) names of the Tags copied from the source - excluding
+// any which are also specified by the user, since these are managed by the user.
+func flattenSnapshotCopiedTagNames(sourceTags *map[string]*string, userTags *map[string]*string) []interface{} {
+	names := make([]string, 0)
+
+	if sourceTags != nil {
+		for k := range *sourceTags {
+			if userTags != nil {
+				if _, ok := (*userTags)[k]; ok {
+					continue
+				}
+			}
+			names = append(names, k)
+		}
+	}
+
+	sort.Strings(names)
+
+	output := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		output = append(output, name)
+	}
+	return output
+}
+
+// snapshotCopiedTagsDiffSuppressFunc ignores the Tags copied from the source (which aren't present in the
+// config) when `copy_tags_from_source` is enabled. Only the Tags which were copied are ignored, so
+// removing a user-specified Tag from the config will still remove it from the Snapshot.
+func snapshotCopiedTagsDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	if !d.Get("copy_tags_from_source").(bool) {
+		return false
+	}
+
+	copiedTagNames := d.Get("copied_tags").([]interface{})
+
+	if k == "tags.%" {
+		// the count only differs due to the copied Tags if it matches once they're retained
+		oldCount, _ := strconv.Atoi(old)
+		o, n := d.GetChange("tags")
+		tags := mergeSnapshotTags(retainSnapshotCopiedTags(o.(map[string]interface{}), copiedTagNames), expandTags(n.(map[string]interface{})))
+		return len(*tags) == oldCount
+	}
+
+	if old == "" || new != "" {
+		return false
+	}
+
+	name := strings.TrimPrefix(k, "tags.")
+	for _, v := range copiedTagNames {
+		if v.(string) == name {
+			return true
+		}
+	}
+
+	return false
+}
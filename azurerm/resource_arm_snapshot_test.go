@@ -2,6 +2,8 @@ package azurerm
 
 import (
 	"fmt"
+	"os"
+	"reflect"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/acctest"
@@ -55,6 +57,272 @@ func TestSnapshotName_validation(t *testing.T) {
 	}
 }
 
+func TestSnapshotSourceURI_validation(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "https://account1.blob.core.windows.net/vhds/disk1.vhd",
+			ErrCount: 0,
+		},
+		{
+			Value:    "https://account1.blob.core.windows.net/vhds/nested/disk1.vhd?sv=2016-05-31&sr=b&sp=r&sig=abc123",
+			ErrCount: 0,
+		},
+		{
+			Value:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Compute/disks/example-disk",
+			ErrCount: 0,
+		},
+		{
+			Value:    "http://account1.blob.core.windows.net/vhds/disk1.vhd",
+			ErrCount: 1,
+		},
+		{
+			Value:    "https://account1.blob.core.windows.net/disk1.vhd",
+			ErrCount: 1,
+		},
+		{
+			Value:    "https://account1.blob.core.windows.net/",
+			ErrCount: 1,
+		},
+		{
+			Value:    "account1.blob.core.windows.net/vhds/disk1.vhd",
+			ErrCount: 2,
+		},
+		{
+			Value:    "/subscriptions/00000000-0000-0000-0000-000000000000",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateSnapshotSourceURI(tc.Value, "source_uri")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected validateSnapshotSourceURI to trigger '%d' errors for %q - got '%d'", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestSnapshotSourceResourceID_validation(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Compute/disks/example-disk",
+			ErrCount: 0,
+		},
+		{
+			Value:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Compute/snapshots/example_snapshot",
+			ErrCount: 0,
+		},
+		{
+			Value:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Compute/restorePointCollections/example-collection/restorePoints/example-restore-point/diskRestorePoints/example-disk_00000000-0000-0000-0000-000000000000",
+			ErrCount: 0,
+		},
+		{
+			Value:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Compute/Disks/example-disk",
+			ErrCount: 0,
+		},
+		{
+			Value:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Storage/storageAccounts/examplestorage",
+			ErrCount: 1,
+		},
+		{
+			Value:    "example-disk",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateSnapshotSourceResourceID(tc.Value, "source_resource_id")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected validateSnapshotSourceResourceID to trigger '%d' errors for %q - got '%d'", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestSnapshotImportSource_validation(t *testing.T) {
+	storageAccountId := "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/group1/providers/Microsoft.Storage/storageAccounts/account1"
+	cases := []struct {
+		CreateOption     string
+		SourceURI        string
+		StorageAccountID string
+		ShouldError      bool
+	}{
+		{
+			CreateOption: "Copy",
+			SourceURI:    "https://account1.blob.core.windows.net/vhds/disk1.vhd",
+			ShouldError:  false,
+		},
+		{
+			CreateOption: "Import",
+			SourceURI:    "https://account1.blob.core.windows.net/vhds/disk1.vhd",
+			ShouldError:  false,
+		},
+		{
+			CreateOption: "import",
+			SourceURI:    "https://account1.blob.core.windows.net/vhds/disk1.vhd",
+			ShouldError:  false,
+		},
+		{
+			CreateOption:     "Import",
+			SourceURI:        "https://account1.blob.core.windows.net/vhds/disk1.vhd",
+			StorageAccountID: storageAccountId,
+			ShouldError:      false,
+		},
+		{
+			CreateOption: "Import",
+			SourceURI:    "https://account1.blob.core.windows.net/vhds/disk1.vhd?sv=2016-05-31&sr=b&sp=r&sig=abc123",
+			ShouldError:  false,
+		},
+		{
+			CreateOption:     "Import",
+			SourceURI:        "https://ACCOUNT1.blob.core.windows.net/vhds/disk1.vhd",
+			StorageAccountID: storageAccountId,
+			ShouldError:      false,
+		},
+		{
+			CreateOption:     "Import",
+			SourceURI:        "https://account2.blob.core.windows.net/vhds/disk1.vhd",
+			StorageAccountID: storageAccountId,
+			ShouldError:      true,
+		},
+		{
+			CreateOption:     "Import",
+			StorageAccountID: storageAccountId,
+			ShouldError:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		err := validateSnapshotImportSource(tc.SourceURI, tc.StorageAccountID)
+		if tc.ShouldError && err == nil {
+			t.Fatalf("Expected an error for Create Option %q / Source URI %q but didn't get one", tc.CreateOption, tc.SourceURI)
+		}
+
+		if !tc.ShouldError && err != nil {
+			t.Fatalf("Expected no error for Create Option %q / Source URI %q but got: %+v", tc.CreateOption, tc.SourceURI, err)
+		}
+	}
+}
+
+func TestSnapshotDiskSizeGB_validation(t *testing.T) {
+	validateFunc := resourceArmSnapshot().Schema["disk_size_gb"].ValidateFunc
+
+	testCases := []struct {
+		Value    int
+		ErrCount int
+	}{
+		{0, 1},
+		{1, 0},
+		{1024, 0},
+		{snapshotMaxDiskSizeGB, 0},
+		{snapshotMaxDiskSizeGB + 1, 1},
+		{65536, 1},
+	}
+
+	for _, tc := range testCases {
+		_, errors := validateFunc(tc.Value, "disk_size_gb")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected `disk_size_gb` of %d to trigger '%d' errors - got '%d'", tc.Value, tc.ErrCount, len(errors))
+		}
+	}
+}
+
+func TestSnapshotCreateOption_diffSuppress(t *testing.T) {
+	suppressFunc := resourceArmSnapshot().Schema["create_option"].DiffSuppressFunc
+	if suppressFunc == nil {
+		t.Fatalf("Expected `create_option` to have a DiffSuppressFunc")
+	}
+
+	testCases := []struct {
+		old      string
+		new      string
+		suppress bool
+	}{
+		{"Copy", "Copy", true},
+		{"Copy", "copy", true},
+		{"Import", "IMPORT", true},
+		{"Copy", "Import", false},
+	}
+
+	for _, test := range testCases {
+		if actual := suppressFunc("create_option", test.old, test.new, nil); actual != test.suppress {
+			t.Fatalf("Expected the diff between %q and %q to be suppressed %t but got %t", test.old, test.new, test.suppress, actual)
+		}
+	}
+}
+
+func TestSnapshotTags_merge(t *testing.T) {
+	sourceTags := map[string]*string{
+		"environment": utils.String("Production"),
+		"cost-center": utils.String("12345"),
+	}
+	userTags := map[string]*string{
+		"environment": utils.String("Backup"),
+		"created-by":  utils.String("Terraform"),
+	}
+
+	expected := map[string]string{
+		"environment": "Backup",
+		"cost-center": "12345",
+		"created-by":  "Terraform",
+	}
+
+	actual := *mergeSnapshotTags(&sourceTags, &userTags)
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected %d tags but got %d", len(expected), len(actual))
+	}
+
+	for k, v := range expected {
+		if actual[k] == nil || *actual[k] != v {
+			t.Fatalf("Expected the tag %q to be %q but got %+v", k, v, actual[k])
+		}
+	}
+
+	if merged := *mergeSnapshotTags(nil, &userTags); len(merged) != len(userTags) {
+		t.Fatalf("Expected %d tags when the source has no tags but got %d", len(userTags), len(merged))
+	}
+}
+
+func TestSnapshotTags_copiedTagNames(t *testing.T) {
+	sourceTags := map[string]*string{
+		"environment": utils.String("Production"),
+		"cost-center": utils.String("12345"),
+		"application": utils.String("Payroll"),
+	}
+	userTags := map[string]*string{
+		"environment": utils.String("Backup"),
+	}
+
+	expected := []interface{}{"application", "cost-center"}
+	if actual := flattenSnapshotCopiedTagNames(&sourceTags, &userTags); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected the copied tag names to be %+v but got %+v", expected, actual)
+	}
+
+	if actual := flattenSnapshotCopiedTagNames(nil, &userTags); len(actual) != 0 {
+		t.Fatalf("Expected no copied tag names when the source has no tags but got %+v", actual)
+	}
+}
+
+func TestSnapshotTags_retainCopiedTags(t *testing.T) {
+	existingTags := map[string]interface{}{
+		"environment": "Backup",
+		"cost-center": "12345",
+	}
+	copiedTagNames := []interface{}{"cost-center", "application"}
+
+	actual := *retainSnapshotCopiedTags(existingTags, copiedTagNames)
+	if len(actual) != 1 || actual["cost-center"] == nil || *actual["cost-center"] != "12345" {
+		t.Fatalf("Expected only the copied tag `cost-center` to be retained but got %+v", actual)
+	}
+}
+
 func TestAccAzureRMSnapshot_fromManagedDisk(t *testing.T) {
 	resourceName := "azurerm_snapshot.test"
 	ri := acctest.RandInt()
@@ -69,6 +337,8 @@ func TestAccAzureRMSnapshot_fromManagedDisk(t *testing.T) {
 				Config: config,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMSnapshotExists(resourceName),
+					// the size is taken from the source Managed Disk, since it's not specified
+					resource.TestCheckResourceAttr(resourceName, "disk_size_gb", "10"),
 				),
 			},
 		},
@@ -123,6 +393,73 @@ func TestAccAzureRMSnapshot_update(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMSnapshot_tags(t *testing.T) {
+	resourceName := "azurerm_snapshot.test"
+	ri := acctest.RandInt()
+	config := testAccAzureRMSnapshot_tags(ri, testLocation())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSnapshotExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "3"),
+					resource.TestCheckResourceAttr(resourceName, "tags.environment", "Production"),
+					resource.TestCheckResourceAttr(resourceName, "tags.cost.center", "12345"),
+					resource.TestCheckResourceAttr(resourceName, "tags.Created By", "Terraform"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMSnapshot_copyTagsFromSource(t *testing.T) {
+	resourceName := "azurerm_snapshot.test"
+	ri := acctest.RandInt()
+	location := testLocation()
+	config := testAccAzureRMSnapshot_copyTagsFromSource(ri, location)
+	updatedConfig := testAccAzureRMSnapshot_copyTagsFromSourceUpdated(ri, location)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSnapshotExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "3"),
+					resource.TestCheckResourceAttr(resourceName, "tags.environment", "Backup"),
+					resource.TestCheckResourceAttr(resourceName, "tags.cost-center", "12345"),
+					resource.TestCheckResourceAttr(resourceName, "tags.created-by", "Terraform"),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSnapshotExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags.environment", "Backup"),
+					resource.TestCheckResourceAttr(resourceName, "tags.cost-center", "12345"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAzureRMSnapshot_extendingManagedDisk(t *testing.T) {
 	resourceName := "azurerm_snapshot.test"
 	ri := acctest.RandInt()
@@ -184,6 +521,34 @@ func TestAccAzureRMSnapshot_fromUnmanagedDisk(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMSnapshot_fromUnmanagedDiskInAnotherSubscription(t *testing.T) {
+	altSubscriptionId := os.Getenv("ARM_SUBSCRIPTION_ID_ALT")
+	if altSubscriptionId == "" {
+		t.Skip("Skipping as ARM_SUBSCRIPTION_ID_ALT isn't specified")
+		return
+	}
+
+	resourceName := "azurerm_snapshot.test"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	config := testAccAzureRMSnapshot_fromUnmanagedDiskInAnotherSubscription(ri, rs, testLocation(), altSubscriptionId)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMSnapshotExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "storage_account_id"),
+				),
+			},
+		},
+	})
+}
+
 func testCheckAzureRMSnapshotDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "azurerm_snapshot" {
@@ -263,6 +628,32 @@ resource "azurerm_snapshot" "test" {
 `, rInt, location, rInt, rInt)
 }
 
+func testAccAzureRMSnapshot_lowerCaseCreateOption(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestrg-%d"
+  location = "%s"
+}
+
+resource "azurerm_managed_disk" "test" {
+  name                 = "acctestmd-%d"
+  location             = "${azurerm_resource_group.test.location}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "10"
+}
+
+resource "azurerm_snapshot" "test" {
+  name                = "acctestss_%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  create_option       = "copy"
+  source_uri          = "${azurerm_managed_disk.test.id}"
+}
+`, rInt, location, rInt, rInt)
+}
+
 func testAccAzureRMSnapshot_fromManagedDiskUpdated(rInt int, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
@@ -293,6 +684,221 @@ resource "azurerm_snapshot" "test" {
 `, rInt, location, rInt, rInt)
 }
 
+func testAccAzureRMSnapshot_tags(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestrg-%d"
+  location = "%s"
+}
+
+resource "azurerm_managed_disk" "test" {
+  name                 = "acctestmd-%d"
+  location             = "${azurerm_resource_group.test.location}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "10"
+}
+
+resource "azurerm_snapshot" "test" {
+  name                = "acctestss_%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  create_option       = "Copy"
+  source_uri          = "${azurerm_managed_disk.test.id}"
+
+  tags {
+    "environment" = "Production"
+    "cost.center" = "12345"
+    "Created By"  = "Terraform"
+  }
+}
+`, rInt, location, rInt, rInt)
+}
+
+func testAccAzureRMSnapshot_copyTagsFromSource(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestrg-%d"
+  location = "%s"
+}
+
+resource "azurerm_managed_disk" "test" {
+  name                 = "acctestmd-%d"
+  location             = "${azurerm_resource_group.test.location}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "10"
+
+  tags {
+    "environment" = "Production"
+    "cost-center" = "12345"
+  }
+}
+
+resource "azurerm_snapshot" "test" {
+  name                  = "acctestss_%d"
+  location              = "${azurerm_resource_group.test.location}"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  create_option         = "Copy"
+  source_resource_id    = "${azurerm_managed_disk.test.id}"
+  copy_tags_from_source = true
+
+  tags {
+    "environment" = "Backup"
+    "created-by"  = "Terraform"
+  }
+}
+`, rInt, location, rInt, rInt)
+}
+
+func testAccAzureRMSnapshot_copyTagsFromSourceUpdated(rInt int, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+  name     = "acctestRG-%d"
+  location = "%s"
+}
+
+resource "azurerm_managed_disk" "test" {
+  name                 = "acctestmd-%d"
+  location             = "${azurerm_resource_group.test.location}"
+  resource_group_name  = "${azurerm_resource_group.test.name}"
+  storage_account_type = "Standard_LRS"
+  create_option        = "Empty"
+  disk_size_gb         = "10"
+
+  tags {
+    "environment" = "Production"
+    "cost-center" = "12345"
+  }
+}
+
+resource "azurerm_snapshot" "test" {
+  name                  = "acctestss_%d"
+  location              = "${azurerm_resource_group.test.location}"
+  resource_group_name   = "${azurerm_resource_group.test.name}"
+  create_option         = "Copy"
+  source_resource_id    = "${azurerm_managed_disk.test.id}"
+  copy_tags_from_source = true
+
+  tags {
+    "environment" = "Backup"
+  }
+}
+`, rInt, location, rInt, rInt)
+}
+
+func testAccAzureRMSnapshot_fromUnmanagedDiskInAnotherSubscription(rInt int, rString string, location string, altSubscriptionId string) string {
+	return fmt.Sprintf(`
+provider "azurerm" {
+  alias           = "alt"
+  subscription_id = "%s"
+}
+
+resource "azurerm_resource_group" "alt" {
+  provider = "azurerm.alt"
+  name     = "acctestrg-alt-%d"
+  location = "%s"
+}
+
+resource "azurerm_virtual_network" "test" {
+  provider            = "azurerm.alt"
+  name                = "acctestvn-%d"
+  address_space       = ["10.0.0.0/16"]
+  location            = "${azurerm_resource_group.alt.location}"
+  resource_group_name = "${azurerm_resource_group.alt.name}"
+}
+
+resource "azurerm_subnet" "test" {
+  provider             = "azurerm.alt"
+  name                 = "acctsub"
+  resource_group_name  = "${azurerm_resource_group.alt.name}"
+  virtual_network_name = "${azurerm_virtual_network.test.name}"
+  address_prefix       = "10.0.2.0/24"
+}
+
+resource "azurerm_network_interface" "test" {
+  provider            = "azurerm.alt"
+  name                = "acctestnic-%d"
+  location            = "${azurerm_resource_group.alt.location}"
+  resource_group_name = "${azurerm_resource_group.alt.name}"
+
+  ip_configuration {
+    name                          = "testconfiguration1"
+    subnet_id                     = "${azurerm_subnet.test.id}"
+    private_ip_address_allocation = "dynamic"
+  }
+}
+
+resource "azurerm_storage_account" "test" {
+  provider                 = "azurerm.alt"
+  name                     = "acctestsa%s"
+  resource_group_name      = "${azurerm_resource_group.alt.name}"
+  location                 = "${azurerm_resource_group.alt.location}"
+  account_tier             = "Standard"
+  account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+  provider              = "azurerm.alt"
+  name                  = "vhds"
+  resource_group_name   = "${azurerm_resource_group.alt.name}"
+  storage_account_name  = "${azurerm_storage_account.test.name}"
+  container_access_type = "private"
+}
+
+resource "azurerm_virtual_machine" "test" {
+  provider                      = "azurerm.alt"
+  name                          = "acctestvm-%d"
+  location                      = "${azurerm_resource_group.alt.location}"
+  resource_group_name           = "${azurerm_resource_group.alt.name}"
+  network_interface_ids         = ["${azurerm_network_interface.test.id}"]
+  vm_size                       = "Standard_A0"
+  delete_os_disk_on_termination = true
+
+  storage_image_reference {
+    publisher = "Canonical"
+    offer     = "UbuntuServer"
+    sku       = "16.04-LTS"
+    version   = "latest"
+  }
+
+  storage_os_disk {
+    name          = "myosdisk1"
+    vhd_uri       = "${azurerm_storage_account.test.primary_blob_endpoint}${azurerm_storage_container.test.name}/myosdisk1.vhd"
+    caching       = "ReadWrite"
+    create_option = "FromImage"
+  }
+
+  os_profile {
+    computer_name  = "acctestvm-%d"
+    admin_username = "testadmin"
+    admin_password = "Password1234!"
+  }
+
+  os_profile_linux_config {
+    disable_password_authentication = false
+  }
+}
+
+resource "azurerm_resource_group" "test" {
+  name     = "acctestrg-%d"
+  location = "%s"
+}
+
+resource "azurerm_snapshot" "test" {
+  name                = "acctestss_%d"
+  location            = "${azurerm_resource_group.test.location}"
+  resource_group_name = "${azurerm_resource_group.test.name}"
+  create_option       = "Import"
+  source_uri          = "${azurerm_storage_account.test.primary_blob_endpoint}${azurerm_storage_container.test.name}/myosdisk1.vhd"
+  storage_account_id  = "${azurerm_storage_account.test.id}"
+  depends_on          = ["azurerm_virtual_machine.test"]
+}
+`, altSubscriptionId, rInt, location, rInt, rInt, rString, rInt, rInt, rInt, location, rInt)
+}
+
 func testAccAzureRMSnapshot_encryption(rInt int, rString string, location string) string {
 	return fmt.Sprintf(`
 data "azurerm_client_config" "current" {}
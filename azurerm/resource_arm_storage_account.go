@@ -3,11 +3,16 @@ package azurerm
 import (
 	"fmt"
 	"log"
+	"net/url"
 	"regexp"
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/arm/resources/resources"
 	"github.com/Azure/azure-sdk-for-go/arm/storage"
+	mainStorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/helper/validation"
@@ -20,6 +25,26 @@ var storageAccountEncryptionSource = "Microsoft.Storage"
 
 const blobStorageAccountDefaultAccessTier = "Hot"
 
+const storageAccountDefaultCreateTimeout = 30 * time.Minute
+
+// the version of the Storage Analytics Metrics configured on the Blob Service
+const storageAccountMetricsVersion = "1.0"
+
+// the Provisioning States reported whilst a Storage Account is being provisioned - zone-redundant accounts
+// can also report intermediate states which aren't defined in the SDK
+var storageAccountPendingProvisioningStates = []string{
+	string(storage.Creating),
+	string(storage.ResolvingDNS),
+	"Updating",
+	"ValidateSubscriptionQuota",
+}
+
+// the Provisioning States of unusable Storage Accounts, which aren't defined in the SDK
+const (
+	storageAccountProvisioningStateDeleting = "Deleting"
+	storageAccountProvisioningStateFailed   = "Failed"
+)
+
 func resourceArmStorageAccount() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmStorageAccountCreate,
@@ -32,6 +57,10 @@ func resourceArmStorageAccount() *schema.Resource {
 		MigrateState:  resourceStorageAccountMigrateState,
 		SchemaVersion: 1,
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(storageAccountDefaultCreateTimeout),
+		},
+
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:         schema.TypeString,
@@ -40,7 +69,13 @@ func resourceArmStorageAccount() *schema.Resource {
 				ValidateFunc: validateArmStorageAccountName,
 			},
 
-			"resource_group_name": resourceGroupNameDiffSuppressSchema(),
+			// Storage Accounts can be moved between Resource Groups in-place, so this isn't ForceNew
+			"resource_group_name": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: resourceAzurermResourceGroupNameDiffSuppress,
+				ValidateFunc:     validateArmResourceGroupName,
+			},
 
 			"location": locationSchema(),
 
@@ -87,6 +122,12 @@ func resourceArmStorageAccount() *schema.Resource {
 				DiffSuppressFunc: ignoreCaseDiffSuppressFunc,
 			},
 
+			"allow_replication_downgrade": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			// Only valid for BlobStorage accounts, defaults to "Hot" in create function
 			"access_tier": {
 				Type:     schema.TypeString,
@@ -133,6 +174,29 @@ func resourceArmStorageAccount() *schema.Resource {
 				Optional: true,
 			},
 
+			"blob_properties": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hour_metrics":   storageAccountMetricsSchema(),
+						"minute_metrics": storageAccountMetricsSchema(),
+					},
+				},
+			},
+
+			"provisioning_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"creation_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"primary_location": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -143,6 +207,16 @@ func resourceArmStorageAccount() *schema.Resource {
 				Computed: true,
 			},
 
+			"status_of_primary": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"status_of_secondary": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"primary_blob_endpoint": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -153,6 +227,16 @@ func resourceArmStorageAccount() *schema.Resource {
 				Computed: true,
 			},
 
+			"primary_blob_host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			"secondary_blob_host": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
 			"primary_queue_endpoint": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -173,12 +257,28 @@ func resourceArmStorageAccount() *schema.Resource {
 				Computed: true,
 			},
 
-			// NOTE: The API does not appear to expose a secondary file endpoint
 			"primary_file_endpoint": {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
 
+			// NOTE: a secondary file endpoint is only returned for some accounts, and is otherwise empty
+			"secondary_file_endpoint": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+
+			// changing either of these values regenerates the associated Access Key
+			"primary_access_key_rotation_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
+			"secondary_access_key_rotation_trigger": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+
 			"primary_access_key": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -189,6 +289,18 @@ func resourceArmStorageAccount() *schema.Resource {
 				Computed: true,
 			},
 
+			"primary_connection_string": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"secondary_connection_string": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
 			"primary_blob_connection_string": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -199,6 +311,30 @@ func resourceArmStorageAccount() *schema.Resource {
 				Computed: true,
 			},
 
+			"primary_file_connection_string": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"secondary_file_connection_string": {
+				Type:      schema.TypeString,
+				Computed:  true,
+				Sensitive: true,
+			},
+
+			"skip_key_retrieval": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
+			"ignore_external_tags": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+
 			"tags": tagsSchema(),
 		},
 	}
@@ -217,10 +353,16 @@ func resourceArmStorageAccountCreate(d *schema.ResourceData, meta interface{}) e
 	tags := d.Get("tags").(map[string]interface{})
 	enableBlobEncryption := d.Get("enable_blob_encryption").(bool)
 	enableHTTPSTrafficOnly := d.Get("enable_https_traffic_only").(bool)
+	if err := validateStorageAccountHTTPSTrafficOnly(client.enforceStorageHTTPSTrafficOnly, storageAccountName, enableHTTPSTrafficOnly); err != nil {
+		return err
+	}
 
 	accountTier := d.Get("account_tier").(string)
 	replicationType := d.Get("account_replication_type").(string)
 	storageType := fmt.Sprintf("%s_%s", accountTier, replicationType)
+	if err := validateStorageAccountSku(accountKind, accountTier, replicationType); err != nil {
+		return err
+	}
 
 	parameters := storage.AccountCreateParameters{
 		Location: &location,
@@ -248,11 +390,16 @@ func resourceArmStorageAccountCreate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	if _, ok := d.GetOk("custom_domain"); ok {
-		parameters.CustomDomain = expandStorageAccountCustomDomain(d)
+		customDomains := d.Get("custom_domain").([]interface{})
+		parameters.CustomDomain = expandStorageAccountCustomDomain(customDomains)
+	}
+
+	if v, ok := d.GetOk("access_tier"); ok && !storageAccountTierSupportsAccessTier(accountTier) {
+		return fmt.Errorf("An `access_tier` (%q) isn't supported for Storage Accounts using the %q `account_tier` - Access Tiers only apply to Standard Storage Accounts", v.(string), accountTier)
 	}
 
 	// AccessTier is only valid for BlobStorage accounts
-	if accountKind == string(storage.BlobStorage) {
+	if storageAccountKindSupportsAccessTier(accountKind) {
 		if string(parameters.Sku.Name) == string(storage.StandardZRS) {
 			return fmt.Errorf("A `account_replication_type` of `ZRS` isn't supported for Blob Storage accounts.")
 		}
@@ -267,16 +414,33 @@ func resourceArmStorageAccountCreate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	// Create
-	_, createError := storageClient.Create(resourceGroupName, storageAccountName, parameters, make(chan struct{}))
-	createErr := <-createError
-
-	// The only way to get the ID back apparently is to read the resource again
-	read, err := storageClient.GetProperties(resourceGroupName, storageAccountName)
+	var account storage.Account
+	createErr := retryOnThrottling(func() (autorest.Response, error) {
+		createResp, createError := storageClient.Create(resourceGroupName, storageAccountName, parameters, make(chan struct{}))
+		account = <-createResp
+		err := <-createError
+		return account.Response, err
+	})
+
+	// The ID is returned from the create result - however if the create failed (or the result
+	// didn't include it) we have to read the resource again to get it
+	var readErr error
+	if account.ID == nil {
+		var read storage.Account
+		readErr = retryOnThrottling(func() (autorest.Response, error) {
+			var err error
+			read, err = storageClient.GetProperties(resourceGroupName, storageAccountName)
+			return read.Response, err
+		})
+		if readErr == nil {
+			account = read
+		}
+	}
 
 	// Set the ID right away if we have one
-	if err == nil && read.ID != nil {
-		log.Printf("[INFO] storage account %q ID: %q", storageAccountName, *read.ID)
-		d.SetId(*read.ID)
+	if account.ID != nil {
+		log.Printf("[INFO] storage account %q ID: %q", storageAccountName, *account.ID)
+		d.SetId(*account.ID)
 	}
 
 	// If we had a create error earlier then we return with that error now.
@@ -288,28 +452,35 @@ func resourceArmStorageAccountCreate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	// Check the read error now that we know it would exist without a create err
-	if err != nil {
-		return err
+	if readErr != nil {
+		return readErr
 	}
 
 	// If we got no ID then the resource group doesn't yet exist
-	if read.ID == nil {
+	if account.ID == nil {
 		return fmt.Errorf("Cannot read Storage Account %q (resource group %q) ID",
 			storageAccountName, resourceGroupName)
 	}
 
 	log.Printf("[DEBUG] Waiting for Storage Account (%s) to become available", storageAccountName)
+	timeout := d.Timeout(schema.TimeoutCreate)
 	stateConf := &resource.StateChangeConf{
-		Pending:    []string{"Updating", "Creating"},
-		Target:     []string{"Succeeded"},
+		Pending:    storageAccountPendingProvisioningStates,
+		Target:     []string{string(storage.Succeeded)},
 		Refresh:    storageAccountStateRefreshFunc(client, resourceGroupName, storageAccountName),
-		Timeout:    30 * time.Minute,
-		MinTimeout: 15 * time.Second,
+		Timeout:    timeout,
+		MinTimeout: storageAccountCreateMinTimeout(timeout),
 	}
 	if _, err := stateConf.WaitForState(); err != nil {
 		return fmt.Errorf("Error waiting for Storage Account (%s) to become available: %s", storageAccountName, err)
 	}
 
+	if v, ok := d.GetOk("blob_properties"); ok {
+		if err := setStorageAccountBlobProperties(client, resourceGroupName, storageAccountName, v.([]interface{})); err != nil {
+			return err
+		}
+	}
+
 	return resourceArmStorageAccountRead(d, meta)
 }
 
@@ -330,14 +501,45 @@ func resourceArmStorageAccountUpdate(d *schema.ResourceData, meta interface{}) e
 	storageType := fmt.Sprintf("%s_%s", accountTier, replicationType)
 	accountKind := d.Get("account_kind").(string)
 
-	if accountKind == string(storage.BlobStorage) {
-		if storageType == string(storage.StandardZRS) {
-			return fmt.Errorf("A `account_replication_type` of `ZRS` isn't supported for Blob Storage accounts.")
+	if err := validateStorageAccountSku(accountKind, accountTier, replicationType); err != nil {
+		return err
+	}
+
+	enableHTTPSTrafficOnly := d.Get("enable_https_traffic_only").(bool)
+	if err := validateStorageAccountHTTPSTrafficOnly(meta.(*ArmClient).enforceStorageHTTPSTrafficOnly, storageAccountName, enableHTTPSTrafficOnly); err != nil {
+		return err
+	}
+
+	// since conditionally forcing a new resource (or erroring) during the diff isn't possible, unsupported or
+	// unconfirmed conversions are checked for prior to making any changes - rather than leaving the Storage
+	// Account partially updated
+	if d.HasChange("account_replication_type") {
+		o, n := d.GetChange("account_replication_type")
+		if storageAccountReplicationChangeRequiresRecreation(o.(string), n.(string)) {
+			return fmt.Errorf("Changing the `account_replication_type` of Storage Account %q from %q to %q isn't supported in-place by Azure - the Storage Account needs to be recreated (for example by using `terraform taint`)", storageAccountName, o, n)
+		}
+
+		if storageAccountReplicationChangeIsDowngrade(o.(string), n.(string)) && !d.Get("allow_replication_downgrade").(bool) {
+			return fmt.Errorf("Changing the `account_replication_type` of Storage Account %q from %q to %q reduces the redundancy of the data stored in it (for example by removing the secondary region) - to confirm this change set `allow_replication_downgrade` to `true`", storageAccountName, o, n)
 		}
 	}
 
 	d.Partial(true)
 
+	if d.HasChange("resource_group_name") {
+		targetResourceGroupName := d.Get("resource_group_name").(string)
+
+		movedId, err := moveStorageAccount(meta.(*ArmClient), d.Id(), targetResourceGroupName)
+		if err != nil {
+			return err
+		}
+
+		d.SetId(movedId)
+		resourceGroupName = targetResourceGroupName
+
+		d.SetPartial("resource_group_name")
+	}
+
 	if d.HasChange("account_replication_type") {
 		sku := storage.Sku{
 			Name: storage.SkuName(storageType),
@@ -346,7 +548,7 @@ func resourceArmStorageAccountUpdate(d *schema.ResourceData, meta interface{}) e
 		opts := storage.AccountUpdateParameters{
 			Sku: &sku,
 		}
-		_, err := client.Update(resourceGroupName, storageAccountName, opts)
+		err := updateStorageAccount(client, resourceGroupName, storageAccountName, opts)
 		if err != nil {
 			return fmt.Errorf("Error updating Azure Storage Account type %q: %+v", storageAccountName, err)
 		}
@@ -357,13 +559,21 @@ func resourceArmStorageAccountUpdate(d *schema.ResourceData, meta interface{}) e
 	if d.HasChange("access_tier") {
 		accessTier := d.Get("access_tier").(string)
 
+		if !storageAccountKindSupportsAccessTier(accountKind) {
+			return fmt.Errorf("`access_tier` can only be changed for Storage Accounts of kind `BlobStorage` - %q is of kind %q", storageAccountName, accountKind)
+		}
+
+		if !storageAccountTierSupportsAccessTier(accountTier) {
+			return fmt.Errorf("An `access_tier` (%q) isn't supported for Storage Accounts using the %q `account_tier` - Access Tiers only apply to Standard Storage Accounts", accessTier, accountTier)
+		}
+
 		opts := storage.AccountUpdateParameters{
 			AccountPropertiesUpdateParameters: &storage.AccountPropertiesUpdateParameters{
 				AccessTier: storage.AccessTier(accessTier),
 			},
 		}
 
-		_, err := client.Update(resourceGroupName, storageAccountName, opts)
+		err := updateStorageAccount(client, resourceGroupName, storageAccountName, opts)
 		if err != nil {
 			return fmt.Errorf("Error updating Azure Storage Account access_tier %q: %+v", storageAccountName, err)
 		}
@@ -372,12 +582,34 @@ func resourceArmStorageAccountUpdate(d *schema.ResourceData, meta interface{}) e
 	}
 
 	if d.HasChange("tags") {
-		tags := d.Get("tags").(map[string]interface{})
+		o, n := d.GetChange("tags")
+		tags := expandTags(n.(map[string]interface{}))
+
+		if d.Get("ignore_external_tags").(bool) {
+			var account storage.Account
+			err := retryOnThrottling(func() (autorest.Response, error) {
+				var err error
+				account, err = client.GetProperties(resourceGroupName, storageAccountName)
+				return account.Response, err
+			})
+			if err != nil {
+				return fmt.Errorf("Error retrieving the existing tags for Azure Storage Account %q: %+v", storageAccountName, err)
+			}
+
+			// when this has only just been enabled (for example after importing, where every tag is read into
+			// the State) it's not known which tags were previously managed - so none of the existing tags are removed
+			oldTags := o.(map[string]interface{})
+			if d.HasChange("ignore_external_tags") {
+				oldTags = map[string]interface{}{}
+			}
+
+			tags = mergeStorageAccountExternalTags(account.Tags, oldTags, n.(map[string]interface{}))
+		}
 
 		opts := storage.AccountUpdateParameters{
-			Tags: expandTags(tags),
+			Tags: tags,
 		}
-		_, err := client.Update(resourceGroupName, storageAccountName, opts)
+		err := updateStorageAccount(client, resourceGroupName, storageAccountName, opts)
 		if err != nil {
 			return fmt.Errorf("Error updating Azure Storage Account tags %q: %+v", storageAccountName, err)
 		}
@@ -413,35 +645,50 @@ func resourceArmStorageAccountUpdate(d *schema.ResourceData, meta interface{}) e
 			d.SetPartial("enable_file_encryption")
 		}
 
-		_, err := client.Update(resourceGroupName, storageAccountName, opts)
+		err := updateStorageAccount(client, resourceGroupName, storageAccountName, opts)
 		if err != nil {
 			return fmt.Errorf("Error updating Azure Storage Account Encryption %q: %+v", storageAccountName, err)
 		}
 	}
 
 	if d.HasChange("custom_domain") {
-		customDomain := expandStorageAccountCustomDomain(d)
+		customDomains := d.Get("custom_domain").([]interface{})
+		customDomain := expandStorageAccountCustomDomain(customDomains)
+		if customDomain == nil {
+			// the Custom Domain is removed by sending an empty name
+			customDomain = &storage.CustomDomain{
+				Name: utils.String(""),
+			}
+		}
+
 		opts := storage.AccountUpdateParameters{
 			AccountPropertiesUpdateParameters: &storage.AccountPropertiesUpdateParameters{
 				CustomDomain: customDomain,
 			},
 		}
 
-		_, err := client.Update(resourceGroupName, storageAccountName, opts)
+		err := updateStorageAccount(client, resourceGroupName, storageAccountName, opts)
 		if err != nil {
 			return fmt.Errorf("Error updating Azure Storage Account Custom Domain %q: %+v", storageAccountName, err)
 		}
 	}
 
-	if d.HasChange("enable_https_traffic_only") {
-		enableHTTPSTrafficOnly := d.Get("enable_https_traffic_only").(bool)
+	if d.HasChange("blob_properties") {
+		blobProperties := d.Get("blob_properties").([]interface{})
+		if err := setStorageAccountBlobProperties(meta.(*ArmClient), resourceGroupName, storageAccountName, blobProperties); err != nil {
+			return err
+		}
 
+		d.SetPartial("blob_properties")
+	}
+
+	if d.HasChange("enable_https_traffic_only") {
 		opts := storage.AccountUpdateParameters{
 			AccountPropertiesUpdateParameters: &storage.AccountPropertiesUpdateParameters{
 				EnableHTTPSTrafficOnly: &enableHTTPSTrafficOnly,
 			},
 		}
-		_, err := client.Update(resourceGroupName, storageAccountName, opts)
+		err := updateStorageAccount(client, resourceGroupName, storageAccountName, opts)
 		if err != nil {
 			return fmt.Errorf("Error updating Azure Storage Account enable_https_traffic_only %q: %+v", storageAccountName, err)
 		}
@@ -449,12 +696,34 @@ func resourceArmStorageAccountUpdate(d *schema.ResourceData, meta interface{}) e
 		d.SetPartial("enable_https_traffic_only")
 	}
 
+	rotations := []struct {
+		trigger string
+		keyName string
+	}{
+		{"primary_access_key_rotation_trigger", "key1"},
+		{"secondary_access_key_rotation_trigger", "key2"},
+	}
+	for _, rotation := range rotations {
+		if !d.HasChange(rotation.trigger) {
+			continue
+		}
+
+		if err := regenerateStorageAccountKey(meta.(*ArmClient), resourceGroupName, storageAccountName, rotation.keyName); err != nil {
+			return err
+		}
+
+		d.SetPartial(rotation.trigger)
+	}
+
 	d.Partial(false)
-	return nil
+
+	// the Access Keys (and Connection Strings) need to be re-read in case they've been regenerated
+	return resourceArmStorageAccountRead(d, meta)
 }
 
 func resourceArmStorageAccountRead(d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*ArmClient).storageServiceClient
+	endpointSuffix := meta.(*ArmClient).environment.StorageEndpointSuffix
 
 	id, err := parseAzureResourceID(d.Id())
 	if err != nil {
@@ -463,7 +732,12 @@ func resourceArmStorageAccountRead(d *schema.ResourceData, meta interface{}) err
 	name := id.Path["storageAccounts"]
 	resGroup := id.ResourceGroup
 
-	resp, err := client.GetProperties(resGroup, name)
+	var resp storage.Account
+	err = retryOnThrottling(func() (autorest.Response, error) {
+		var err error
+		resp, err = client.GetProperties(resGroup, name)
+		return resp.Response, err
+	})
 	if err != nil {
 		if utils.ResponseWasNotFound(resp.Response) {
 			d.SetId("")
@@ -472,31 +746,81 @@ func resourceArmStorageAccountRead(d *schema.ResourceData, meta interface{}) err
 		return fmt.Errorf("Error reading the state of AzureRM Storage Account %q: %+v", name, err)
 	}
 
-	keys, err := client.ListKeys(resGroup, name)
-	if err != nil {
-		return err
+	if props := resp.AccountProperties; props != nil {
+		deleting, failed := checkStorageAccountProvisioningState(props.ProvisioningState)
+		if deleting {
+			log.Printf("[DEBUG] Storage Account %q (resource group %q) is being deleted, removing from state", name, resGroup)
+			d.SetId("")
+			return nil
+		}
+
+		// this isn't returned as an error, since that'd prevent the account being refreshed - and as such being tainted or destroyed
+		if failed {
+			log.Printf("[WARN] Storage Account %q (resource group %q) is in a %q Provisioning State and is unusable - it needs to be recreated (for example using `terraform taint`)", name, resGroup, props.ProvisioningState)
+		}
+	}
+
+	// the Access Keys (and the Connection Strings which use them) are left empty when they're not
+	// retrieved, for example when the credentials in use aren't permitted to call ListKeys
+	primaryKey := ""
+	secondaryKey := ""
+	if !d.Get("skip_key_retrieval").(bool) {
+		var keys storage.AccountListKeysResult
+		err = retryOnThrottling(func() (autorest.Response, error) {
+			var err error
+			keys, err = client.ListKeys(resGroup, name)
+			return keys.Response, err
+		})
+		if err != nil {
+			return err
+		}
+
+		if accessKeys := keys.Keys; accessKeys != nil {
+			if len(*accessKeys) > 0 && (*accessKeys)[0].Value != nil {
+				primaryKey = *(*accessKeys)[0].Value
+			}
+			if len(*accessKeys) > 1 && (*accessKeys)[1].Value != nil {
+				secondaryKey = *(*accessKeys)[1].Value
+			}
+		}
 	}
 
-	accessKeys := *keys.Keys
 	d.Set("name", resp.Name)
 	d.Set("resource_group_name", resGroup)
 	d.Set("location", azureRMNormalizeLocation(*resp.Location))
 	d.Set("account_kind", resp.Kind)
 
+	// these aren't returned from the API, but need to be set so that they're defaulted when importing
+	d.Set("allow_replication_downgrade", d.Get("allow_replication_downgrade").(bool))
+	d.Set("skip_key_retrieval", d.Get("skip_key_retrieval").(bool))
+	d.Set("ignore_external_tags", d.Get("ignore_external_tags").(bool))
+
 	if sku := resp.Sku; sku != nil {
 		d.Set("account_type", sku.Name)
 		d.Set("account_tier", sku.Tier)
-		d.Set("account_replication_type", strings.Split(fmt.Sprintf("%v", sku.Name), "_")[1])
+
+		replicationType, err := parseStorageAccountReplicationType(string(sku.Name))
+		if err != nil {
+			return fmt.Errorf("Error reading Storage Account %q (resource group %q): %+v", name, resGroup, err)
+		}
+		d.Set("account_replication_type", replicationType)
 	}
 
 	if props := resp.AccountProperties; props != nil {
+		// the Access Tier is always set from the API rather than defaulted, so that
+		// BlobStorage accounts imported with a `Cool` Access Tier don't show a diff
 		d.Set("access_tier", props.AccessTier)
-		d.Set("enable_https_traffic_only", props.EnableHTTPSTrafficOnly)
 
-		if customDomain := props.CustomDomain; customDomain != nil {
-			if err := d.Set("custom_domain", flattenStorageAccountCustomDomain(customDomain)); err != nil {
-				return fmt.Errorf("Error flattening `custom_domain`: %+v", err)
-			}
+		// older accounts don't return this field at all, which is the same as it being disabled
+		enableHTTPSTrafficOnly := false
+		if props.EnableHTTPSTrafficOnly != nil {
+			enableHTTPSTrafficOnly = *props.EnableHTTPSTrafficOnly
+		}
+		d.Set("enable_https_traffic_only", enableHTTPSTrafficOnly)
+
+		// always set the Custom Domain, so that removing it outside of Terraform is detected
+		if err := d.Set("custom_domain", flattenStorageAccountCustomDomain(props.CustomDomain)); err != nil {
+			return fmt.Errorf("Error flattening `custom_domain`: %+v", err)
 		}
 
 		if encryption := props.Encryption; encryption != nil {
@@ -511,49 +835,72 @@ func resourceArmStorageAccountRead(d *schema.ResourceData, meta interface{}) err
 		}
 
 		// Computed
+		d.Set("provisioning_state", string(props.ProvisioningState))
+
+		d.Set("creation_time", flattenStorageAccountCreationTime(props.CreationTime))
+
 		d.Set("primary_location", props.PrimaryLocation)
 		d.Set("secondary_location", props.SecondaryLocation)
 
-		if endpoints := props.PrimaryEndpoints; endpoints != nil {
-			d.Set("primary_blob_endpoint", endpoints.Blob)
-			d.Set("primary_queue_endpoint", endpoints.Queue)
-			d.Set("primary_table_endpoint", endpoints.Table)
-			d.Set("primary_file_endpoint", endpoints.File)
-
-			pscs := fmt.Sprintf("DefaultEndpointsProtocol=https;BlobEndpoint=%s;AccountName=%s;AccountKey=%s",
-				*endpoints.Blob, *resp.Name, *accessKeys[0].Value)
-			d.Set("primary_blob_connection_string", pscs)
-		}
-
-		if endpoints := props.SecondaryEndpoints; endpoints != nil {
-			if blob := endpoints.Blob; blob != nil {
-				d.Set("secondary_blob_endpoint", blob)
-				sscs := fmt.Sprintf("DefaultEndpointsProtocol=https;BlobEndpoint=%s;AccountName=%s;AccountKey=%s",
-					*blob, *resp.Name, *accessKeys[1].Value)
-				d.Set("secondary_blob_connection_string", sscs)
-			} else {
-				d.Set("secondary_blob_endpoint", "")
-				d.Set("secondary_blob_connection_string", "")
-			}
-
-			if endpoints.Queue != nil {
-				d.Set("secondary_queue_endpoint", endpoints.Queue)
-			} else {
-				d.Set("secondary_queue_endpoint", "")
-			}
+		// the Secondary Location (and its status) are only returned for geo-replicated accounts
+		d.Set("status_of_primary", string(props.StatusOfPrimary))
+		d.Set("status_of_secondary", string(props.StatusOfSecondary))
+
+		// every endpoint (and connection string) is always set, so that values which are no longer
+		// returned (for example the secondary endpoints, once geo-replication is disabled) are removed
+		primary := flattenStorageAccountEndpoints(*resp.Name, primaryKey, endpointSuffix, props.PrimaryEndpoints)
+		d.Set("primary_blob_endpoint", primary.blobEndpoint)
+		d.Set("primary_blob_host", primary.blobHost)
+		d.Set("primary_queue_endpoint", primary.queueEndpoint)
+		d.Set("primary_table_endpoint", primary.tableEndpoint)
+		d.Set("primary_file_endpoint", primary.fileEndpoint)
+		d.Set("primary_connection_string", primary.connectionString)
+		d.Set("primary_blob_connection_string", primary.blobConnectionString)
+		d.Set("primary_file_connection_string", primary.fileConnectionString)
+
+		secondary := flattenStorageAccountEndpoints(*resp.Name, secondaryKey, endpointSuffix, props.SecondaryEndpoints)
+		d.Set("secondary_blob_endpoint", secondary.blobEndpoint)
+		d.Set("secondary_blob_host", secondary.blobHost)
+		d.Set("secondary_queue_endpoint", secondary.queueEndpoint)
+		d.Set("secondary_table_endpoint", secondary.tableEndpoint)
+		d.Set("secondary_file_endpoint", secondary.fileEndpoint)
+		d.Set("secondary_connection_string", secondary.connectionString)
+		d.Set("secondary_blob_connection_string", secondary.blobConnectionString)
+		d.Set("secondary_file_connection_string", secondary.fileConnectionString)
+	}
 
-			if endpoints.Table != nil {
-				d.Set("secondary_table_endpoint", endpoints.Table)
-			} else {
-				d.Set("secondary_table_endpoint", "")
+	// the Blob Service Properties are always retrieved (so that they're imported, and changes made outside of
+	// Terraform are detected) - other than when the Access Keys needed to call the Storage Account's data plane
+	// aren't retrieved, or for Premium accounts where Storage Analytics Metrics aren't available
+	if !d.Get("skip_key_retrieval").(bool) && (resp.Sku == nil || resp.Sku.Tier != storage.Premium) {
+		// the data plane may not be reachable (e.g. due to Firewall rules), so failing to retrieve the
+		// Blob Service Properties shouldn't fail the refresh of the Storage Account itself
+		blobClient, accountExists, err := meta.(*ArmClient).getBlobStorageClientForStorageAccount(resGroup, name, "")
+		if err != nil {
+			log.Printf("[WARN] Error building the Blob Client for Storage Account %q (resource group %q), skipping `blob_properties`: %+v", name, resGroup, err)
+		} else if !accountExists {
+			log.Printf("[DEBUG] Storage Account %q (resource group %q) no longer exists, removing from state", name, resGroup)
+			d.SetId("")
+			return nil
+		} else {
+			serviceProps, err := blobClient.GetServiceProperties()
+			if err != nil {
+				log.Printf("[WARN] Error retrieving the Blob Service Properties for Storage Account %q (resource group %q), skipping `blob_properties`: %+v", name, resGroup, err)
+			} else if err := d.Set("blob_properties", flattenStorageAccountBlobProperties(serviceProps)); err != nil {
+				return fmt.Errorf("Error flattening `blob_properties`: %+v", err)
 			}
 		}
 	}
 
-	d.Set("primary_access_key", accessKeys[0].Value)
-	d.Set("secondary_access_key", accessKeys[1].Value)
+	d.Set("primary_access_key", primaryKey)
+	d.Set("secondary_access_key", secondaryKey)
 
-	flattenAndSetTags(d, resp.Tags)
+	// when importing `ignore_external_tags` isn't yet known, so every tag is imported
+	tags := resp.Tags
+	if d.Get("ignore_external_tags").(bool) {
+		tags = filterStorageAccountManagedTags(resp.Tags, d.Get("tags").(map[string]interface{}))
+	}
+	flattenAndSetTags(d, tags)
 
 	return nil
 }
@@ -573,12 +920,123 @@ func resourceArmStorageAccountDelete(d *schema.ResourceData, meta interface{}) e
 		return fmt.Errorf("Error issuing AzureRM delete request for storage account %q: %+v", name, err)
 	}
 
+	// a Storage Account with the same name will have different keys
+	meta.(*ArmClient).storageAccountKeys.invalidate(resGroup, name)
+
 	return nil
 }
 
-func expandStorageAccountCustomDomain(d *schema.ResourceData) *storage.CustomDomain {
-	domains := d.Get("custom_domain").([]interface{})
-	domain := domains[0].(map[string]interface{})
+// moveStorageAccount moves the specified Storage Account into another Resource Group within the
+// same Subscription, returning the new Resource ID of the Storage Account
+func moveStorageAccount(client *ArmClient, storageAccountId string, targetResourceGroupName string) (string, error) {
+	id, err := parseAzureResourceID(storageAccountId)
+	if err != nil {
+		return "", err
+	}
+	storageAccountName := id.Path["storageAccounts"]
+	sourceResourceGroupName := id.ResourceGroup
+
+	if err := validateStorageAccountMove(id, client.subscriptionId); err != nil {
+		return "", err
+	}
+
+	// the Resource Group name is scoped to the Subscription the Provider is configured for
+	targetResourceGroup, err := client.resourceGroupClient.Get(targetResourceGroupName)
+	if err != nil {
+		if utils.ResponseWasNotFound(targetResourceGroup.Response) {
+			return "", fmt.Errorf("Error moving Storage Account %q: Resource Group %q was not found in Subscription %q", storageAccountName, targetResourceGroupName, client.subscriptionId)
+		}
+
+		return "", fmt.Errorf("Error retrieving Resource Group %q: %+v", targetResourceGroupName, err)
+	}
+
+	log.Printf("[INFO] Moving Storage Account %q from Resource Group %q to %q", storageAccountName, sourceResourceGroupName, targetResourceGroupName)
+	parameters := resources.MoveInfo{
+		ResourcesProperty:   &[]string{storageAccountId},
+		TargetResourceGroup: targetResourceGroup.ID,
+	}
+	_, moveErr := client.resourceFindClient.MoveResources(sourceResourceGroupName, parameters, make(chan struct{}))
+	if err := <-moveErr; err != nil {
+		return "", fmt.Errorf("Error moving Storage Account %q from Resource Group %q to %q: %+v", storageAccountName, sourceResourceGroupName, targetResourceGroupName, err)
+	}
+
+	account, err := client.storageServiceClient.GetProperties(targetResourceGroupName, storageAccountName)
+	if err != nil {
+		return "", fmt.Errorf("Error retrieving Storage Account %q (Resource Group %q) after moving it: %+v", storageAccountName, targetResourceGroupName, err)
+	}
+
+	if account.ID == nil {
+		return "", fmt.Errorf("Cannot read Storage Account %q (resource group %q) ID", storageAccountName, targetResourceGroupName)
+	}
+
+	return *account.ID, nil
+}
+
+// validateStorageAccountMove ensures that the Storage Account belongs to the Subscription the Provider
+// is configured for, since Storage Accounts can only be moved between Resource Groups in-place
+// within the same Subscription
+func validateStorageAccountMove(id *ResourceID, subscriptionId string) error {
+	if !strings.EqualFold(id.SubscriptionID, subscriptionId) {
+		return fmt.Errorf("Storage Account %q can only be moved between Resource Groups within the Subscription %q - but it's in the Subscription %q", id.Path["storageAccounts"], subscriptionId, id.SubscriptionID)
+	}
+
+	return nil
+}
+
+// checkStorageAccountProvisioningState returns whether the Storage Account is being deleted, or has
+// failed to provision. The API returns these accounts, however neither is usable.
+// These states aren't defined in the SDK, so they're compared case-insensitively.
+func checkStorageAccountProvisioningState(state storage.ProvisioningState) (deleting bool, failed bool) {
+	deleting = strings.EqualFold(string(state), storageAccountProvisioningStateDeleting)
+	failed = strings.EqualFold(string(state), storageAccountProvisioningStateFailed)
+	return
+}
+
+// regenerateStorageAccountKey regenerates the specified Access Key (either `key1` or `key2`) for the
+// Storage Account, ensuring the cached key used by the Data Plane resources isn't used afterwards
+func regenerateStorageAccountKey(client *ArmClient, resourceGroupName string, storageAccountName string, keyName string) error {
+	log.Printf("[INFO] Regenerating the Access Key %q for Storage Account %q (resource group %q)", keyName, storageAccountName, resourceGroupName)
+
+	parameters := storage.AccountRegenerateKeyParameters{
+		KeyName: utils.String(keyName),
+	}
+	err := retryOnThrottling(func() (autorest.Response, error) {
+		resp, err := client.storageServiceClient.RegenerateKey(resourceGroupName, storageAccountName, parameters)
+		return resp.Response, err
+	})
+	if err != nil {
+		return fmt.Errorf("Error regenerating the Access Key %q for Storage Account %q (resource group %q): %+v", keyName, storageAccountName, resourceGroupName, err)
+	}
+
+	client.storageAccountKeys.invalidate(resourceGroupName, storageAccountName)
+
+	return nil
+}
+
+// validateStorageAccountHTTPSTrafficOnly ensures that `enable_https_traffic_only` is enabled when this
+// is enforced via the Provider block - allowing this to be required without changing every configuration
+func validateStorageAccountHTTPSTrafficOnly(enforced bool, storageAccountName string, enableHTTPSTrafficOnly bool) error {
+	if !enforced || enableHTTPSTrafficOnly {
+		return nil
+	}
+
+	return fmt.Errorf("`enable_https_traffic_only` must be set to `true` for Storage Account %q, since `enforce_storage_https_traffic_only` is enabled in the Provider block", storageAccountName)
+}
+
+// updateStorageAccount updates the specified Storage Account, retrying if the request is throttled
+func updateStorageAccount(client storage.AccountsClient, resourceGroupName string, storageAccountName string, opts storage.AccountUpdateParameters) error {
+	return retryOnThrottling(func() (autorest.Response, error) {
+		resp, err := client.Update(resourceGroupName, storageAccountName, opts)
+		return resp.Response, err
+	})
+}
+
+func expandStorageAccountCustomDomain(input []interface{}) *storage.CustomDomain {
+	if len(input) == 0 || input[0] == nil {
+		return nil
+	}
+
+	domain := input[0].(map[string]interface{})
 	name := domain["name"].(string)
 	useSubDomain := domain["use_subdomain"].(bool)
 	return &storage.CustomDomain{
@@ -588,6 +1046,11 @@ func expandStorageAccountCustomDomain(d *schema.ResourceData) *storage.CustomDom
 }
 
 func flattenStorageAccountCustomDomain(input *storage.CustomDomain) []interface{} {
+	// a cleared Custom Domain is returned either as nil or with an empty name
+	if input == nil || input.Name == nil || *input.Name == "" {
+		return []interface{}{}
+	}
+
 	domain := make(map[string]interface{}, 0)
 
 	domain["name"] = *input.Name
@@ -596,6 +1059,388 @@ func flattenStorageAccountCustomDomain(input *storage.CustomDomain) []interface{
 	return []interface{}{domain}
 }
 
+func storageAccountMetricsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Computed: true,
+		MaxItems: 1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"enabled": {
+					Type:     schema.TypeBool,
+					Required: true,
+				},
+
+				"include_apis": {
+					Type:     schema.TypeBool,
+					Optional: true,
+					Default:  false,
+				},
+
+				// a value of 0 disables the Retention Policy, meaning metrics are retained indefinitely
+				"retention_policy_days": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      0,
+					ValidateFunc: validation.IntBetween(0, 365),
+				},
+			},
+		},
+	}
+}
+
+func setStorageAccountBlobProperties(client *ArmClient, resourceGroupName string, storageAccountName string, input []interface{}) error {
+	blobClient, accountExists, err := client.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName, "")
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q (resource group %q) was not found", storageAccountName, resourceGroupName)
+	}
+
+	props := expandStorageAccountBlobProperties(input)
+	if err := blobClient.SetServiceProperties(props); err != nil {
+		return fmt.Errorf("Error updating the Blob Service Properties for Storage Account %q (resource group %q): %+v", storageAccountName, resourceGroupName, err)
+	}
+
+	return nil
+}
+
+func expandStorageAccountBlobProperties(input []interface{}) mainStorage.ServiceProperties {
+	props := mainStorage.ServiceProperties{
+		HourMetrics:   expandStorageAccountMetrics([]interface{}{}),
+		MinuteMetrics: expandStorageAccountMetrics([]interface{}{}),
+	}
+
+	if len(input) == 0 || input[0] == nil {
+		return props
+	}
+
+	blobProperties := input[0].(map[string]interface{})
+	props.HourMetrics = expandStorageAccountMetrics(blobProperties["hour_metrics"].([]interface{}))
+	props.MinuteMetrics = expandStorageAccountMetrics(blobProperties["minute_metrics"].([]interface{}))
+
+	return props
+}
+
+func expandStorageAccountMetrics(input []interface{}) *mainStorage.Metrics {
+	metrics := &mainStorage.Metrics{
+		Version: storageAccountMetricsVersion,
+		Enabled: false,
+		RetentionPolicy: &mainStorage.RetentionPolicy{
+			Enabled: false,
+		},
+	}
+
+	if len(input) == 0 || input[0] == nil {
+		return metrics
+	}
+
+	v := input[0].(map[string]interface{})
+	metrics.Enabled = v["enabled"].(bool)
+
+	// IncludeAPIs can only be specified when the metrics are enabled
+	if metrics.Enabled {
+		metrics.IncludeAPIs = utils.Bool(v["include_apis"].(bool))
+	}
+
+	if days := v["retention_policy_days"].(int); days > 0 {
+		metrics.RetentionPolicy = &mainStorage.RetentionPolicy{
+			Enabled: true,
+			Days:    utils.Int(days),
+		}
+	}
+
+	return metrics
+}
+
+func flattenStorageAccountBlobProperties(input *mainStorage.ServiceProperties) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	blobProperties := map[string]interface{}{
+		"hour_metrics":   flattenStorageAccountMetrics(input.HourMetrics),
+		"minute_metrics": flattenStorageAccountMetrics(input.MinuteMetrics),
+	}
+
+	return []interface{}{blobProperties}
+}
+
+func flattenStorageAccountMetrics(input *mainStorage.Metrics) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	metrics := map[string]interface{}{
+		"enabled":               input.Enabled,
+		"include_apis":          false,
+		"retention_policy_days": 0,
+	}
+
+	if input.IncludeAPIs != nil {
+		metrics["include_apis"] = *input.IncludeAPIs
+	}
+
+	if policy := input.RetentionPolicy; policy != nil && policy.Enabled && policy.Days != nil {
+		metrics["retention_policy_days"] = *policy.Days
+	}
+
+	return []interface{}{metrics}
+}
+
+// storageAccountCreateMinTimeout returns the minimum time to wait between polling the
+// provisioning state of a Storage Account. This scales with the Create timeout, such that
+// a shorter timeout (for example in test environments) polls more frequently.
+func storageAccountCreateMinTimeout(timeout time.Duration) time.Duration {
+	minTimeout := timeout / 120
+
+	if minTimeout < time.Second {
+		return time.Second
+	}
+
+	if minTimeout > 15*time.Second {
+		return 15 * time.Second
+	}
+
+	return minTimeout
+}
+
+// storageAccountKindSupportsAccessTier returns whether the `access_tier` can be set
+// for Storage Accounts of the specified Kind
+func storageAccountKindSupportsAccessTier(kind string) bool {
+	return strings.EqualFold(kind, string(storage.BlobStorage))
+}
+
+// storageAccountTierSupportsAccessTier returns whether the `access_tier` can be set
+// for Storage Accounts using the specified Tier
+func storageAccountTierSupportsAccessTier(tier string) bool {
+	return !strings.EqualFold(tier, string(storage.Premium))
+}
+
+// mergeStorageAccountExternalTags returns the Tags to assign to a Storage Account when `ignore_external_tags`
+// is enabled: the existing (e.g. Azure Policy assigned) Tags are retained, other than those which were
+// previously managed by Terraform and have been removed - with the Tags in the config taking precedence.
+func mergeStorageAccountExternalTags(existing *map[string]*string, oldTags map[string]interface{}, newTags map[string]interface{}) *map[string]*string {
+	output := make(map[string]*string)
+
+	if existing != nil {
+		for k, v := range *existing {
+			if _, wasManaged := oldTags[k]; wasManaged {
+				continue
+			}
+			output[k] = v
+		}
+	}
+
+	for k, v := range *expandTags(newTags) {
+		output[k] = v
+	}
+
+	return &output
+}
+
+// filterStorageAccountManagedTags returns only the Tags which are managed by Terraform, so that any
+// Tags assigned outside of Terraform don't show a diff when `ignore_external_tags` is enabled.
+func filterStorageAccountManagedTags(tags *map[string]*string, managedTags map[string]interface{}) *map[string]*string {
+	output := make(map[string]*string)
+
+	if tags != nil {
+		for k, v := range *tags {
+			if _, ok := managedTags[k]; ok {
+				output[k] = v
+			}
+		}
+	}
+
+	return &output
+}
+
+// validateStorageAccountSku ensures the combination of Kind, Tier and Replication Type is supported by Azure:
+// Premium accounts only support Locally Redundant Storage, and Blob Storage accounts are Standard-only
+// and don't support Zone Redundant Storage. Since this can't be validated at plan time (as it spans
+// multiple fields) it's checked prior to creating/updating the account, rather than failing part-way through.
+func validateStorageAccountSku(kind string, tier string, replicationType string) error {
+	supportedSkus := []storage.SkuName{
+		storage.StandardLRS,
+		storage.StandardGRS,
+		storage.StandardRAGRS,
+		storage.StandardZRS,
+		storage.PremiumLRS,
+	}
+	if strings.EqualFold(kind, string(storage.BlobStorage)) {
+		supportedSkus = []storage.SkuName{
+			storage.StandardLRS,
+			storage.StandardGRS,
+			storage.StandardRAGRS,
+		}
+	}
+
+	skuName := fmt.Sprintf("%s_%s", tier, replicationType)
+	supported := make([]string, 0, len(supportedSkus))
+	for _, sku := range supportedSkus {
+		if strings.EqualFold(skuName, string(sku)) {
+			return nil
+		}
+
+		parts := strings.SplitN(string(sku), "_", 2)
+		supported = append(supported, fmt.Sprintf("%s/%s", parts[0], parts[1]))
+	}
+
+	return fmt.Errorf("The combination of `account_kind` %q, `account_tier` %q and `account_replication_type` %q isn't supported - supported `account_tier`/`account_replication_type` combinations for %q accounts are: %s", kind, tier, replicationType, kind, strings.Join(supported, ", "))
+}
+
+// flattenStorageAccountCreationTime returns the Creation Time in RFC3339 format (in UTC), so that
+// it can be compared with other timestamps (e.g. from `timestamp()`) in the config
+func flattenStorageAccountCreationTime(input *date.Time) string {
+	if input == nil {
+		return ""
+	}
+
+	return input.UTC().Format(time.RFC3339)
+}
+
+// parseStorageAccountReplicationType returns the Replication Type from a SKU Name, which is in
+// the format `{Tier}_{ReplicationType}` (e.g. `Standard_RAGRS`). Anything after the first
+// underscore is treated as the Replication Type, so that longer SKU Names aren't truncated.
+func parseStorageAccountReplicationType(skuName string) (string, error) {
+	parts := strings.SplitN(skuName, "_", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("Unable to parse the Replication Type from the SKU Name %q: expected it to be in the format `{Tier}_{ReplicationType}`", skuName)
+	}
+
+	return parts[1], nil
+}
+
+// storageAccountReplicationChangeRequiresRecreation returns whether changing the Replication
+// Type of a Storage Account requires it to be recreated, rather than being updated in-place.
+// Azure doesn't support converting to or from Zone Redundant Storage.
+func storageAccountReplicationChangeRequiresRecreation(oldType string, newType string) bool {
+	if strings.EqualFold(oldType, newType) {
+		return false
+	}
+
+	return strings.EqualFold(oldType, "ZRS") || strings.EqualFold(newType, "ZRS")
+}
+
+// storageAccountReplicationChangeIsDowngrade returns whether changing the Replication Type of
+// a Storage Account reduces its redundancy - for example from RAGRS to LRS, which removes
+// the (readable) secondary region.
+func storageAccountReplicationChangeIsDowngrade(oldType string, newType string) bool {
+	redundancy := map[string]int{
+		"lrs":   0,
+		"zrs":   1,
+		"grs":   2,
+		"ragrs": 3,
+	}
+
+	o, ok := redundancy[strings.ToLower(oldType)]
+	if !ok {
+		return false
+	}
+
+	n, ok := redundancy[strings.ToLower(newType)]
+	if !ok {
+		return false
+	}
+
+	return n < o
+}
+
+type storageAccountEndpoints struct {
+	blobEndpoint         string
+	blobHost             string
+	queueEndpoint        string
+	tableEndpoint        string
+	fileEndpoint         string
+	connectionString     string
+	blobConnectionString string
+	fileConnectionString string
+}
+
+// flattenStorageAccountEndpoints returns the endpoints and connection strings for either the primary or
+// secondary location of a Storage Account - which are empty when they're not returned from the API
+func flattenStorageAccountEndpoints(accountName string, accountKey string, endpointSuffix string, input *storage.Endpoints) storageAccountEndpoints {
+	output := storageAccountEndpoints{}
+	if input == nil {
+		return output
+	}
+
+	if input.Blob != nil {
+		output.blobEndpoint = *input.Blob
+
+		// the host is exposed separately, since it's needed without the scheme/trailing slash (e.g. for DNS records)
+		if uri, err := url.Parse(*input.Blob); err == nil {
+			output.blobHost = uri.Host
+		}
+
+		if accountKey != "" {
+			blobEndpoints := &storage.Endpoints{
+				Blob: input.Blob,
+			}
+			output.blobConnectionString = buildStorageAccountConnectionString(accountName, accountKey, endpointSuffix, blobEndpoints)
+		}
+	}
+
+	if input.Queue != nil {
+		output.queueEndpoint = *input.Queue
+	}
+
+	if input.Table != nil {
+		output.tableEndpoint = *input.Table
+	}
+
+	if input.File != nil {
+		output.fileEndpoint = *input.File
+
+		if accountKey != "" {
+			fileEndpoints := &storage.Endpoints{
+				File: input.File,
+			}
+			output.fileConnectionString = buildStorageAccountConnectionString(accountName, accountKey, endpointSuffix, fileEndpoints)
+		}
+	}
+
+	// a Connection String is only usable with an Access Key, which isn't available when `skip_key_retrieval` is set
+	if accountKey != "" {
+		output.connectionString = buildStorageAccountConnectionString(accountName, accountKey, endpointSuffix, input)
+	}
+
+	return output
+}
+
+// buildStorageAccountConnectionString returns a Connection String containing
+// each of the Service Endpoints available for the Storage Account. The Endpoint
+// Suffix comes from the Azure Environment the Provider's configured for, so that
+// the Connection String is valid in the Sovereign Clouds (e.g. China / Government)
+func buildStorageAccountConnectionString(accountName string, accountKey string, endpointSuffix string, endpoints *storage.Endpoints) string {
+	components := []string{"DefaultEndpointsProtocol=https"}
+
+	if endpoints.Blob != nil {
+		components = append(components, fmt.Sprintf("BlobEndpoint=%s", *endpoints.Blob))
+	}
+
+	if endpoints.Queue != nil {
+		components = append(components, fmt.Sprintf("QueueEndpoint=%s", *endpoints.Queue))
+	}
+
+	if endpoints.Table != nil {
+		components = append(components, fmt.Sprintf("TableEndpoint=%s", *endpoints.Table))
+	}
+
+	if endpoints.File != nil {
+		components = append(components, fmt.Sprintf("FileEndpoint=%s", *endpoints.File))
+	}
+
+	components = append(components,
+		fmt.Sprintf("AccountName=%s", accountName),
+		fmt.Sprintf("AccountKey=%s", accountKey),
+		fmt.Sprintf("EndpointSuffix=%s", endpointSuffix))
+
+	return strings.Join(components, ";")
+}
+
 func validateArmStorageAccountName(v interface{}, k string) (ws []string, es []error) {
 	input := v.(string)
 
@@ -624,11 +1469,21 @@ func validateArmStorageAccountType(v interface{}, k string) (ws []string, es []e
 
 func storageAccountStateRefreshFunc(client *ArmClient, resourceGroupName string, storageAccountName string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
-		res, err := client.storageServiceClient.GetProperties(resourceGroupName, storageAccountName)
+		var res storage.Account
+		err := retryOnThrottling(func() (autorest.Response, error) {
+			var err error
+			res, err = client.storageServiceClient.GetProperties(resourceGroupName, storageAccountName)
+			return res.Response, err
+		})
 		if err != nil {
 			return nil, "", fmt.Errorf("Error issuing read request in storageAccountStateRefreshFunc to Azure ARM for Storage Account '%s' (RG: '%s'): %s", storageAccountName, resourceGroupName, err)
 		}
 
+		// the properties may not be populated immediately after creation, in which case we poll again
+		if res.AccountProperties == nil {
+			return nil, "", nil
+		}
+
 		return res, string(res.AccountProperties.ProvisioningState), nil
 	}
 }
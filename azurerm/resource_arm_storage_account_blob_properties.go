@@ -0,0 +1,137 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceArmStorageAccountBlobProperties() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmStorageAccountBlobPropertiesCreateUpdate,
+		Read:   resourceArmStorageAccountBlobPropertiesRead,
+		Update: resourceArmStorageAccountBlobPropertiesCreateUpdate,
+		Delete: resourceArmStorageAccountBlobPropertiesDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"storage_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStorageAccountID,
+			},
+
+			"hour_metrics":   storageAccountMetricsSchema(),
+			"minute_metrics": storageAccountMetricsSchema(),
+		},
+	}
+}
+
+func validateStorageAccountID(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+
+	id, err := parseAzureResourceID(value)
+	if err != nil {
+		errors = append(errors, fmt.Errorf("Error parsing %q as a Resource ID: %+v", k, err))
+		return
+	}
+
+	if id.Path["storageAccounts"] == "" || len(id.Path) != 1 {
+		errors = append(errors, fmt.Errorf("%q must be the ID of a Storage Account: %q", k, value))
+	}
+	return
+}
+
+func resourceArmStorageAccountBlobPropertiesCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient)
+
+	storageAccountId := d.Get("storage_account_id").(string)
+	id, err := parseAzureResourceID(storageAccountId)
+	if err != nil {
+		return err
+	}
+	storageAccountName := id.Path["storageAccounts"]
+	resourceGroupName := id.ResourceGroup
+
+	blobProperties := []interface{}{
+		map[string]interface{}{
+			"hour_metrics":   d.Get("hour_metrics").([]interface{}),
+			"minute_metrics": d.Get("minute_metrics").([]interface{}),
+		},
+	}
+
+	log.Printf("[INFO] Updating the Blob Service Properties for Storage Account %q (resource group %q)", storageAccountName, resourceGroupName)
+	if err := setStorageAccountBlobProperties(client, resourceGroupName, storageAccountName, blobProperties); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/blobServices/default", strings.TrimSuffix(storageAccountId, "/")))
+
+	return resourceArmStorageAccountBlobPropertiesRead(d, meta)
+}
+
+func resourceArmStorageAccountBlobPropertiesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient)
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	storageAccountName := id.Path["storageAccounts"]
+	resourceGroupName := id.ResourceGroup
+
+	blobClient, accountExists, err := client.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName, "")
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		log.Printf("[DEBUG] Storage Account %q (resource group %q) no longer exists, removing Blob Service Properties from state", storageAccountName, resourceGroupName)
+		d.SetId("")
+		return nil
+	}
+
+	serviceProps, err := blobClient.GetServiceProperties()
+	if err != nil {
+		return fmt.Errorf("Error retrieving the Blob Service Properties for Storage Account %q (resource group %q): %+v", storageAccountName, resourceGroupName, err)
+	}
+
+	d.Set("storage_account_id", strings.TrimSuffix(d.Id(), "/blobServices/default"))
+
+	if err := d.Set("hour_metrics", flattenStorageAccountMetrics(serviceProps.HourMetrics)); err != nil {
+		return fmt.Errorf("Error flattening `hour_metrics`: %+v", err)
+	}
+
+	if err := d.Set("minute_metrics", flattenStorageAccountMetrics(serviceProps.MinuteMetrics)); err != nil {
+		return fmt.Errorf("Error flattening `minute_metrics`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceArmStorageAccountBlobPropertiesDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient)
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	storageAccountName := id.Path["storageAccounts"]
+	resourceGroupName := id.ResourceGroup
+
+	// the Blob Service can't be removed, so instead the properties are reset to their defaults
+	_, accountExists, err := client.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName, "")
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return nil
+	}
+
+	log.Printf("[INFO] Resetting the Blob Service Properties for Storage Account %q (resource group %q)", storageAccountName, resourceGroupName)
+	return setStorageAccountBlobProperties(client, resourceGroupName, storageAccountName, []interface{}{})
+}
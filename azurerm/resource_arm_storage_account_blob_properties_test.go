@@ -0,0 +1,173 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestValidateStorageAccountID(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Storage/storageAccounts/examplestorage",
+			ErrCount: 0,
+		},
+		{
+			Value:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources",
+			ErrCount: 1,
+		},
+		{
+			Value:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Network/virtualNetworks/example-network",
+			ErrCount: 1,
+		},
+		{
+			Value:    "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example-resources/providers/Microsoft.Storage/storageAccounts/examplestorage/blobServices/default",
+			ErrCount: 1,
+		},
+		{
+			Value:    "examplestorage",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateStorageAccountID(tc.Value, "storage_account_id")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected validateStorageAccountID to trigger '%d' errors for %q - got '%d'", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
+
+func TestAccAzureRMStorageAccountBlobProperties_basic(t *testing.T) {
+	resourceName := "azurerm_storage_account_blob_properties.test"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	location := testLocation()
+	config := testAccAzureRMStorageAccountBlobProperties_basic(ri, rs, location)
+	updatedConfig := testAccAzureRMStorageAccountBlobProperties_updated(ri, rs, location)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountBlobPropertiesHourMetricsEnabled(resourceName, true),
+					resource.TestCheckResourceAttr(resourceName, "hour_metrics.0.enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "hour_metrics.0.retention_policy_days", "7"),
+					resource.TestCheckResourceAttr(resourceName, "minute_metrics.0.enabled", "false"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountBlobPropertiesHourMetricsEnabled(resourceName, false),
+					resource.TestCheckResourceAttr(resourceName, "hour_metrics.0.enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "minute_metrics.0.enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "minute_metrics.0.include_apis", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMStorageAccountBlobPropertiesHourMetricsEnabled(name string, enabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		id, err := parseAzureResourceID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		storageAccountName := id.Path["storageAccounts"]
+		resourceGroup := id.ResourceGroup
+
+		armClient := testAccProvider.Meta().(*ArmClient)
+		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName, "")
+		if err != nil {
+			return err
+		}
+		if !accountExists {
+			return fmt.Errorf("Bad: Storage Account %q (resource group %q) does not exist", storageAccountName, resourceGroup)
+		}
+
+		props, err := blobClient.GetServiceProperties()
+		if err != nil {
+			return fmt.Errorf("Bad: Get on blobClient.GetServiceProperties: %+v", err)
+		}
+
+		if actual := props.HourMetrics != nil && props.HourMetrics.Enabled; actual != enabled {
+			return fmt.Errorf("Bad: expected Hour Metrics enabled to be %t but got %t", enabled, actual)
+		}
+
+		return nil
+	}
+}
+
+func testAccAzureRMStorageAccountBlobProperties_basic(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+    name = "testAccAzureRMSA-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.testrg.name}"
+    location = "${azurerm_resource_group.testrg.location}"
+    account_tier = "Standard"
+    account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_account_blob_properties" "test" {
+    storage_account_id = "${azurerm_storage_account.testsa.id}"
+
+    hour_metrics {
+        enabled = true
+        retention_policy_days = 7
+    }
+}
+`, rInt, location, rString)
+}
+
+func testAccAzureRMStorageAccountBlobProperties_updated(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+    name = "testAccAzureRMSA-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.testrg.name}"
+    location = "${azurerm_resource_group.testrg.location}"
+    account_tier = "Standard"
+    account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_account_blob_properties" "test" {
+    storage_account_id = "${azurerm_storage_account.testsa.id}"
+
+    hour_metrics {
+        enabled = false
+    }
+
+    minute_metrics {
+        enabled = true
+        include_apis = true
+    }
+}
+`, rInt, location, rString)
+}
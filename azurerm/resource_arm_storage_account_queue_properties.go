@@ -0,0 +1,365 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	mainStorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+const (
+	// the version of the Storage Analytics Logging settings supported by the API
+	storageAccountLoggingVersion = "1.0"
+
+	// the maximum time a CORS preflight response can be cached for, as allowed by the API
+	storageAccountCorsMaxAgeInSeconds = 2000000000
+)
+
+func resourceArmStorageAccountQueueProperties() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmStorageAccountQueuePropertiesCreateUpdate,
+		Read:   resourceArmStorageAccountQueuePropertiesRead,
+		Update: resourceArmStorageAccountQueuePropertiesCreateUpdate,
+		Delete: resourceArmStorageAccountQueuePropertiesDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"storage_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStorageAccountID,
+			},
+
+			"logging": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Computed: true,
+				MaxItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"delete": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"read": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						"write": {
+							Type:     schema.TypeBool,
+							Required: true,
+						},
+
+						// a value of 0 disables the Retention Policy, meaning logs are retained indefinitely
+						"retention_policy_days": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      0,
+							ValidateFunc: validation.IntBetween(0, 365),
+						},
+					},
+				},
+			},
+
+			"hour_metrics":   storageAccountMetricsSchema(),
+			"minute_metrics": storageAccountMetricsSchema(),
+
+			"cors_rule": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: 5,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"allowed_origins": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							MaxItems: 64,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringLenBetween(1, 256),
+							},
+						},
+
+						"allowed_methods": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{
+									"DELETE",
+									"GET",
+									"HEAD",
+									"MERGE",
+									"POST",
+									"OPTIONS",
+									"PUT",
+								}, false),
+							},
+						},
+
+						"allowed_headers": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 64,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"exposed_headers": {
+							Type:     schema.TypeList,
+							Required: true,
+							MaxItems: 64,
+							Elem:     &schema.Schema{Type: schema.TypeString},
+						},
+
+						"max_age_in_seconds": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, storageAccountCorsMaxAgeInSeconds),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceArmStorageAccountQueuePropertiesCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient)
+
+	storageAccountId := d.Get("storage_account_id").(string)
+	id, err := parseAzureResourceID(storageAccountId)
+	if err != nil {
+		return err
+	}
+	storageAccountName := id.Path["storageAccounts"]
+	resourceGroupName := id.ResourceGroup
+
+	props := mainStorage.ServiceProperties{
+		Logging:       expandStorageAccountLogging(d.Get("logging").([]interface{})),
+		HourMetrics:   expandStorageAccountMetrics(d.Get("hour_metrics").([]interface{})),
+		MinuteMetrics: expandStorageAccountMetrics(d.Get("minute_metrics").([]interface{})),
+		Cors:          expandStorageAccountCorsRules(d.Get("cors_rule").([]interface{})),
+	}
+
+	log.Printf("[INFO] Updating the Queue Service Properties for Storage Account %q (resource group %q)", storageAccountName, resourceGroupName)
+	if err := setStorageAccountQueueProperties(client, resourceGroupName, storageAccountName, props); err != nil {
+		return err
+	}
+
+	d.SetId(fmt.Sprintf("%s/queueServices/default", strings.TrimSuffix(storageAccountId, "/")))
+
+	return resourceArmStorageAccountQueuePropertiesRead(d, meta)
+}
+
+func resourceArmStorageAccountQueuePropertiesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient)
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	storageAccountName := id.Path["storageAccounts"]
+	resourceGroupName := id.ResourceGroup
+
+	queueClient, accountExists, err := client.getQueueServiceClientForStorageAccount(resourceGroupName, storageAccountName, "")
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		log.Printf("[DEBUG] Storage Account %q (resource group %q) no longer exists, removing Queue Service Properties from state", storageAccountName, resourceGroupName)
+		d.SetId("")
+		return nil
+	}
+
+	serviceProps, err := queueClient.GetServiceProperties()
+	if err != nil {
+		return fmt.Errorf("Error retrieving the Queue Service Properties for Storage Account %q (resource group %q): %+v", storageAccountName, resourceGroupName, err)
+	}
+
+	d.Set("storage_account_id", strings.TrimSuffix(d.Id(), "/queueServices/default"))
+
+	if err := d.Set("logging", flattenStorageAccountLogging(serviceProps.Logging)); err != nil {
+		return fmt.Errorf("Error flattening `logging`: %+v", err)
+	}
+
+	if err := d.Set("hour_metrics", flattenStorageAccountMetrics(serviceProps.HourMetrics)); err != nil {
+		return fmt.Errorf("Error flattening `hour_metrics`: %+v", err)
+	}
+
+	if err := d.Set("minute_metrics", flattenStorageAccountMetrics(serviceProps.MinuteMetrics)); err != nil {
+		return fmt.Errorf("Error flattening `minute_metrics`: %+v", err)
+	}
+
+	if err := d.Set("cors_rule", flattenStorageAccountCorsRules(serviceProps.Cors)); err != nil {
+		return fmt.Errorf("Error flattening `cors_rule`: %+v", err)
+	}
+
+	return nil
+}
+
+func resourceArmStorageAccountQueuePropertiesDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient)
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	storageAccountName := id.Path["storageAccounts"]
+	resourceGroupName := id.ResourceGroup
+
+	// the Queue Service can't be removed, so instead the properties are reset to their defaults
+	_, accountExists, err := client.getQueueServiceClientForStorageAccount(resourceGroupName, storageAccountName, "")
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return nil
+	}
+
+	props := mainStorage.ServiceProperties{
+		Logging:       expandStorageAccountLogging([]interface{}{}),
+		HourMetrics:   expandStorageAccountMetrics([]interface{}{}),
+		MinuteMetrics: expandStorageAccountMetrics([]interface{}{}),
+		Cors:          expandStorageAccountCorsRules([]interface{}{}),
+	}
+
+	log.Printf("[INFO] Resetting the Queue Service Properties for Storage Account %q (resource group %q)", storageAccountName, resourceGroupName)
+	return setStorageAccountQueueProperties(client, resourceGroupName, storageAccountName, props)
+}
+
+func setStorageAccountQueueProperties(client *ArmClient, resourceGroupName string, storageAccountName string, props mainStorage.ServiceProperties) error {
+	queueClient, accountExists, err := client.getQueueServiceClientForStorageAccount(resourceGroupName, storageAccountName, "")
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q (resource group %q) was not found", storageAccountName, resourceGroupName)
+	}
+
+	if err := queueClient.SetServiceProperties(props); err != nil {
+		return fmt.Errorf("Error updating the Queue Service Properties for Storage Account %q (resource group %q): %+v", storageAccountName, resourceGroupName, err)
+	}
+
+	return nil
+}
+
+func expandStorageAccountLogging(input []interface{}) *mainStorage.Logging {
+	// when the block is removed logging is disabled, rather than left as-is
+	logging := &mainStorage.Logging{
+		Version: storageAccountLoggingVersion,
+		RetentionPolicy: &mainStorage.RetentionPolicy{
+			Enabled: false,
+		},
+	}
+
+	if len(input) == 0 || input[0] == nil {
+		return logging
+	}
+
+	v := input[0].(map[string]interface{})
+	logging.Delete = v["delete"].(bool)
+	logging.Read = v["read"].(bool)
+	logging.Write = v["write"].(bool)
+
+	if days := v["retention_policy_days"].(int); days > 0 {
+		logging.RetentionPolicy = &mainStorage.RetentionPolicy{
+			Enabled: true,
+			Days:    utils.Int(days),
+		}
+	}
+
+	return logging
+}
+
+func flattenStorageAccountLogging(input *mainStorage.Logging) []interface{} {
+	if input == nil {
+		return []interface{}{}
+	}
+
+	logging := map[string]interface{}{
+		"delete":                input.Delete,
+		"read":                  input.Read,
+		"write":                 input.Write,
+		"retention_policy_days": 0,
+	}
+
+	if policy := input.RetentionPolicy; policy != nil && policy.Enabled && policy.Days != nil {
+		logging["retention_policy_days"] = *policy.Days
+	}
+
+	return []interface{}{logging}
+}
+
+func expandStorageAccountCorsRules(input []interface{}) *mainStorage.Cors {
+	// an empty list of rules removes any existing rules
+	cors := &mainStorage.Cors{
+		CorsRule: make([]mainStorage.CorsRule, 0),
+	}
+
+	for _, raw := range input {
+		v := raw.(map[string]interface{})
+
+		cors.CorsRule = append(cors.CorsRule, mainStorage.CorsRule{
+			AllowedOrigins:  joinStorageAccountCorsValues(v["allowed_origins"].([]interface{})),
+			AllowedMethods:  joinStorageAccountCorsValues(v["allowed_methods"].([]interface{})),
+			AllowedHeaders:  joinStorageAccountCorsValues(v["allowed_headers"].([]interface{})),
+			ExposedHeaders:  joinStorageAccountCorsValues(v["exposed_headers"].([]interface{})),
+			MaxAgeInSeconds: v["max_age_in_seconds"].(int),
+		})
+	}
+
+	return cors
+}
+
+func flattenStorageAccountCorsRules(input *mainStorage.Cors) []interface{} {
+	output := make([]interface{}, 0)
+	if input == nil {
+		return output
+	}
+
+	for _, rule := range input.CorsRule {
+		output = append(output, map[string]interface{}{
+			"allowed_origins":    splitStorageAccountCorsValues(rule.AllowedOrigins),
+			"allowed_methods":    splitStorageAccountCorsValues(rule.AllowedMethods),
+			"allowed_headers":    splitStorageAccountCorsValues(rule.AllowedHeaders),
+			"exposed_headers":    splitStorageAccountCorsValues(rule.ExposedHeaders),
+			"max_age_in_seconds": rule.MaxAgeInSeconds,
+		})
+	}
+
+	return output
+}
+
+// the API represents each of the CORS values as a comma-separated string
+func joinStorageAccountCorsValues(input []interface{}) string {
+	values := make([]string, 0, len(input))
+	for _, v := range input {
+		values = append(values, v.(string))
+	}
+
+	return strings.Join(values, ",")
+}
+
+func splitStorageAccountCorsValues(input string) []interface{} {
+	output := make([]interface{}, 0)
+	if input == "" {
+		return output
+	}
+
+	for _, v := range strings.Split(input, ",") {
+		output = append(output, strings.TrimSpace(v))
+	}
+
+	return output
+}
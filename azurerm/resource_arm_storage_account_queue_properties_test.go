@@ -0,0 +1,294 @@
+package azurerm
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	mainStorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestExpandStorageAccountLogging(t *testing.T) {
+	disabled := expandStorageAccountLogging([]interface{}{})
+	if disabled.Delete || disabled.Read || disabled.Write {
+		t.Fatalf("Expected logging to be disabled when no block is specified but got %+v", disabled)
+	}
+	if disabled.Version != storageAccountLoggingVersion {
+		t.Fatalf("Expected the Version to be %q but got %q", storageAccountLoggingVersion, disabled.Version)
+	}
+	if disabled.RetentionPolicy == nil || disabled.RetentionPolicy.Enabled {
+		t.Fatalf("Expected the Retention Policy to be disabled but got %+v", disabled.RetentionPolicy)
+	}
+
+	logging := expandStorageAccountLogging([]interface{}{
+		map[string]interface{}{
+			"delete":                true,
+			"read":                  false,
+			"write":                 true,
+			"retention_policy_days": 7,
+		},
+	})
+	if !logging.Delete || logging.Read || !logging.Write {
+		t.Fatalf("Expected Delete and Write logging to be enabled but got %+v", logging)
+	}
+	if !logging.RetentionPolicy.Enabled || *logging.RetentionPolicy.Days != 7 {
+		t.Fatalf("Expected a Retention Policy of 7 days but got %+v", logging.RetentionPolicy)
+	}
+}
+
+func TestFlattenStorageAccountLogging(t *testing.T) {
+	if output := flattenStorageAccountLogging(nil); len(output) != 0 {
+		t.Fatalf("Expected no logging block when the API returns none but got %+v", output)
+	}
+
+	output := flattenStorageAccountLogging(&mainStorage.Logging{})
+	expected := []interface{}{
+		map[string]interface{}{
+			"delete":                false,
+			"read":                  false,
+			"write":                 false,
+			"retention_policy_days": 0,
+		},
+	}
+	if !reflect.DeepEqual(output, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, output)
+	}
+
+	days := 14
+	output = flattenStorageAccountLogging(&mainStorage.Logging{
+		Read: true,
+		RetentionPolicy: &mainStorage.RetentionPolicy{
+			Enabled: true,
+			Days:    &days,
+		},
+	})
+	expected = []interface{}{
+		map[string]interface{}{
+			"delete":                false,
+			"read":                  true,
+			"write":                 false,
+			"retention_policy_days": 14,
+		},
+	}
+	if !reflect.DeepEqual(output, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, output)
+	}
+}
+
+func TestExpandFlattenStorageAccountCorsRules(t *testing.T) {
+	input := []interface{}{
+		map[string]interface{}{
+			"allowed_origins":    []interface{}{"https://example.com", "https://example.org"},
+			"allowed_methods":    []interface{}{"GET", "PUT"},
+			"allowed_headers":    []interface{}{"x-ms-meta-*"},
+			"exposed_headers":    []interface{}{},
+			"max_age_in_seconds": 3600,
+		},
+	}
+
+	cors := expandStorageAccountCorsRules(input)
+	if len(cors.CorsRule) != 1 {
+		t.Fatalf("Expected 1 CORS Rule but got %d", len(cors.CorsRule))
+	}
+
+	rule := cors.CorsRule[0]
+	if rule.AllowedOrigins != "https://example.com,https://example.org" {
+		t.Fatalf("Expected the Allowed Origins to be comma-separated but got %q", rule.AllowedOrigins)
+	}
+	if rule.AllowedMethods != "GET,PUT" {
+		t.Fatalf("Expected the Allowed Methods to be comma-separated but got %q", rule.AllowedMethods)
+	}
+	if rule.ExposedHeaders != "" {
+		t.Fatalf("Expected no Exposed Headers but got %q", rule.ExposedHeaders)
+	}
+
+	if output := flattenStorageAccountCorsRules(cors); !reflect.DeepEqual(output, input) {
+		t.Fatalf("Expected the flattened CORS Rules to be %+v but got %+v", input, output)
+	}
+
+	if empty := expandStorageAccountCorsRules([]interface{}{}); empty == nil || len(empty.CorsRule) != 0 {
+		t.Fatalf("Expected an empty list of CORS Rules when none are specified but got %+v", empty)
+	}
+}
+
+func TestStorageAccountCorsRule_validation(t *testing.T) {
+	corsRuleSchema := resourceArmStorageAccountQueueProperties().Schema["cors_rule"].Elem.(*schema.Resource).Schema
+
+	maxAgeCases := []struct {
+		Value    int
+		ErrCount int
+	}{
+		{-1, 1},
+		{0, 0},
+		{3600, 0},
+		{storageAccountCorsMaxAgeInSeconds, 0},
+		{storageAccountCorsMaxAgeInSeconds + 1, 1},
+	}
+
+	for _, tc := range maxAgeCases {
+		_, errors := corsRuleSchema["max_age_in_seconds"].ValidateFunc(tc.Value, "max_age_in_seconds")
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected `max_age_in_seconds` of %d to trigger '%d' errors - got '%d'", tc.Value, tc.ErrCount, len(errors))
+		}
+	}
+
+	for _, key := range []string{"allowed_origins", "allowed_methods"} {
+		if corsRuleSchema[key].MinItems != 1 {
+			t.Fatalf("Expected %q to require at least one item but got a minimum of %d", key, corsRuleSchema[key].MinItems)
+		}
+	}
+
+	if _, errors := corsRuleSchema["allowed_origins"].Elem.(*schema.Schema).ValidateFunc("", "allowed_origins.0"); len(errors) == 0 {
+		t.Fatalf("Expected an empty origin in `allowed_origins` to be invalid")
+	}
+}
+
+func TestAccAzureRMStorageAccountQueueProperties_basic(t *testing.T) {
+	resourceName := "azurerm_storage_account_queue_properties.test"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	location := testLocation()
+	config := testAccAzureRMStorageAccountQueueProperties_basic(ri, rs, location)
+	updatedConfig := testAccAzureRMStorageAccountQueueProperties_updated(ri, rs, location)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountQueuePropertiesLoggingEnabled(resourceName, true),
+					resource.TestCheckResourceAttr(resourceName, "logging.0.delete", "true"),
+					resource.TestCheckResourceAttr(resourceName, "logging.0.retention_policy_days", "7"),
+					resource.TestCheckResourceAttr(resourceName, "hour_metrics.0.enabled", "true"),
+					resource.TestCheckResourceAttr(resourceName, "cors_rule.#", "0"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountQueuePropertiesLoggingEnabled(resourceName, false),
+					resource.TestCheckResourceAttr(resourceName, "logging.0.delete", "false"),
+					resource.TestCheckResourceAttr(resourceName, "hour_metrics.0.enabled", "false"),
+					resource.TestCheckResourceAttr(resourceName, "cors_rule.#", "1"),
+					resource.TestCheckResourceAttr(resourceName, "cors_rule.0.allowed_methods.#", "2"),
+					resource.TestCheckResourceAttr(resourceName, "cors_rule.0.max_age_in_seconds", "3600"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMStorageAccountQueuePropertiesLoggingEnabled(name string, enabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		id, err := parseAzureResourceID(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		storageAccountName := id.Path["storageAccounts"]
+		resourceGroup := id.ResourceGroup
+
+		armClient := testAccProvider.Meta().(*ArmClient)
+		queueClient, accountExists, err := armClient.getQueueServiceClientForStorageAccount(resourceGroup, storageAccountName, "")
+		if err != nil {
+			return err
+		}
+		if !accountExists {
+			return fmt.Errorf("Bad: Storage Account %q (resource group %q) does not exist", storageAccountName, resourceGroup)
+		}
+
+		props, err := queueClient.GetServiceProperties()
+		if err != nil {
+			return fmt.Errorf("Bad: Get on queueClient.GetServiceProperties: %+v", err)
+		}
+
+		if actual := props.Logging != nil && props.Logging.Delete; actual != enabled {
+			return fmt.Errorf("Bad: expected Delete Logging enabled to be %t but got %t", enabled, actual)
+		}
+
+		return nil
+	}
+}
+
+func testAccAzureRMStorageAccountQueueProperties_basic(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+    name = "testAccAzureRMSA-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.testrg.name}"
+    location = "${azurerm_resource_group.testrg.location}"
+    account_tier = "Standard"
+    account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_account_queue_properties" "test" {
+    storage_account_id = "${azurerm_storage_account.testsa.id}"
+
+    logging {
+        delete = true
+        read = true
+        write = true
+        retention_policy_days = 7
+    }
+
+    hour_metrics {
+        enabled = true
+        retention_policy_days = 7
+    }
+}
+`, rInt, location, rString)
+}
+
+func testAccAzureRMStorageAccountQueueProperties_updated(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+    name = "testAccAzureRMSA-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.testrg.name}"
+    location = "${azurerm_resource_group.testrg.location}"
+    account_tier = "Standard"
+    account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_account_queue_properties" "test" {
+    storage_account_id = "${azurerm_storage_account.testsa.id}"
+
+    logging {
+        delete = false
+        read = false
+        write = false
+    }
+
+    hour_metrics {
+        enabled = false
+    }
+
+    cors_rule {
+        allowed_origins = ["https://example.com"]
+        allowed_methods = ["GET", "PUT"]
+        allowed_headers = ["x-ms-meta-*"]
+        exposed_headers = ["x-ms-meta-*"]
+        max_age_in_seconds = 3600
+    }
+}
+`, rInt, location, rString)
+}
@@ -0,0 +1,228 @@
+package azurerm
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/arm/storage"
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+var storageAccountTagResourceName = "azurerm_storage_account_tag"
+
+func resourceArmStorageAccountTag() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceArmStorageAccountTagCreateUpdate,
+		Read:   resourceArmStorageAccountTagRead,
+		Update: resourceArmStorageAccountTagCreateUpdate,
+		Delete: resourceArmStorageAccountTagDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"storage_account_id": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStorageAccountID,
+			},
+
+			"key": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validateStorageAccountTagKey,
+			},
+
+			"value": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+		},
+	}
+}
+
+func resourceArmStorageAccountTagCreateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).storageServiceClient
+
+	storageAccountId := d.Get("storage_account_id").(string)
+	id, err := parseAzureResourceID(storageAccountId)
+	if err != nil {
+		return err
+	}
+	storageAccountName := id.Path["storageAccounts"]
+	resourceGroupName := id.ResourceGroup
+
+	key := d.Get("key").(string)
+	value := d.Get("value").(string)
+
+	// multiple tags can be managed on the same Storage Account, so updates to it are serialized
+	azureRMLockByName(storageAccountName, storageAccountTagResourceName)
+	defer azureRMUnlockByName(storageAccountName, storageAccountTagResourceName)
+
+	account, err := getStorageAccountForTag(client, resourceGroupName, storageAccountName)
+	if err != nil {
+		return fmt.Errorf("Error retrieving Storage Account %q (resource group %q): %+v", storageAccountName, resourceGroupName, err)
+	}
+
+	// the tag is merged into the existing tags, overwriting any existing value for this key
+	opts := storage.AccountUpdateParameters{
+		Tags: setStorageAccountTag(account.Tags, key, value),
+	}
+
+	log.Printf("[INFO] Setting the tag %q on Storage Account %q (resource group %q)", key, storageAccountName, resourceGroupName)
+	if err := updateStorageAccount(client, resourceGroupName, storageAccountName, opts); err != nil {
+		return fmt.Errorf("Error setting the tag %q on Storage Account %q (resource group %q): %+v", key, storageAccountName, resourceGroupName, err)
+	}
+
+	d.SetId(fmt.Sprintf("%s/tags/%s", strings.TrimSuffix(storageAccountId, "/"), key))
+
+	return resourceArmStorageAccountTagRead(d, meta)
+}
+
+func resourceArmStorageAccountTagRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).storageServiceClient
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	storageAccountName := id.Path["storageAccounts"]
+	resourceGroupName := id.ResourceGroup
+	key := id.Path["tags"]
+
+	account, err := getStorageAccountForTag(client, resourceGroupName, storageAccountName)
+	if err != nil {
+		if utils.ResponseWasNotFound(account.Response) {
+			log.Printf("[DEBUG] Storage Account %q (resource group %q) no longer exists, removing tag %q from state", storageAccountName, resourceGroupName, key)
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Storage Account %q (resource group %q): %+v", storageAccountName, resourceGroupName, err)
+	}
+
+	value, exists := flattenStorageAccountTagsMap(account.Tags)[key]
+	if !exists {
+		log.Printf("[DEBUG] Tag %q no longer exists on Storage Account %q (resource group %q), removing from state", key, storageAccountName, resourceGroupName)
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("storage_account_id", strings.TrimSuffix(d.Id(), fmt.Sprintf("/tags/%s", key)))
+	d.Set("key", key)
+	d.Set("value", value)
+
+	return nil
+}
+
+func resourceArmStorageAccountTagDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*ArmClient).storageServiceClient
+
+	id, err := parseAzureResourceID(d.Id())
+	if err != nil {
+		return err
+	}
+	storageAccountName := id.Path["storageAccounts"]
+	resourceGroupName := id.ResourceGroup
+	key := id.Path["tags"]
+
+	azureRMLockByName(storageAccountName, storageAccountTagResourceName)
+	defer azureRMUnlockByName(storageAccountName, storageAccountTagResourceName)
+
+	account, err := getStorageAccountForTag(client, resourceGroupName, storageAccountName)
+	if err != nil {
+		if utils.ResponseWasNotFound(account.Response) {
+			return nil
+		}
+		return fmt.Errorf("Error retrieving Storage Account %q (resource group %q): %+v", storageAccountName, resourceGroupName, err)
+	}
+
+	if _, exists := flattenStorageAccountTagsMap(account.Tags)[key]; !exists {
+		return nil
+	}
+
+	opts := storage.AccountUpdateParameters{
+		Tags: removeStorageAccountTag(account.Tags, key),
+	}
+
+	log.Printf("[INFO] Removing the tag %q from Storage Account %q (resource group %q)", key, storageAccountName, resourceGroupName)
+	if err := updateStorageAccount(client, resourceGroupName, storageAccountName, opts); err != nil {
+		return fmt.Errorf("Error removing the tag %q from Storage Account %q (resource group %q): %+v", key, storageAccountName, resourceGroupName, err)
+	}
+
+	return nil
+}
+
+func getStorageAccountForTag(client storage.AccountsClient, resourceGroupName string, storageAccountName string) (storage.Account, error) {
+	var account storage.Account
+	err := retryOnThrottling(func() (autorest.Response, error) {
+		var err error
+		account, err = client.GetProperties(resourceGroupName, storageAccountName)
+		return account.Response, err
+	})
+	return account, err
+}
+
+// flattenStorageAccountTagsMap returns the tags as plain strings, treating an empty value as an empty string
+func flattenStorageAccountTagsMap(tags *map[string]*string) map[string]string {
+	output := make(map[string]string)
+	if tags == nil {
+		return output
+	}
+
+	for k, v := range *tags {
+		output[k] = ""
+		if v != nil {
+			output[k] = *v
+		}
+	}
+
+	return output
+}
+
+// setStorageAccountTag returns a copy of the existing tags with the specified key set, leaving all other tags intact
+func setStorageAccountTag(existing *map[string]*string, key string, value string) *map[string]*string {
+	output := make(map[string]*string)
+	if existing != nil {
+		for k, v := range *existing {
+			output[k] = v
+		}
+	}
+
+	output[key] = utils.String(value)
+
+	return &output
+}
+
+// removeStorageAccountTag returns a copy of the existing tags without the specified key, leaving all other tags intact
+func removeStorageAccountTag(existing *map[string]*string, key string) *map[string]*string {
+	output := make(map[string]*string)
+	if existing != nil {
+		for k, v := range *existing {
+			if k != key {
+				output[k] = v
+			}
+		}
+	}
+
+	return &output
+}
+
+func validateStorageAccountTagKey(v interface{}, k string) (ws []string, es []error) {
+	value := v.(string)
+
+	if len(value) == 0 || len(value) > 512 {
+		es = append(es, fmt.Errorf("%q must be between 1 and 512 characters in length", k))
+	}
+
+	// the key forms part of the Resource ID, so it can't contain any of the characters which Azure disallows in tag names
+	if strings.ContainsAny(value, "<>%&\\?/") {
+		es = append(es, fmt.Errorf("%q cannot contain any of the characters `<`, `>`, `%%`, `&`, `\\`, `?` or `/`", k))
+	}
+
+	return
+}
@@ -0,0 +1,170 @@
+package azurerm
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+func TestSetStorageAccountTag(t *testing.T) {
+	existing := map[string]*string{
+		"environment": utils.String("production"),
+		"cost-center": utils.String("1234"),
+	}
+
+	output := flattenStorageAccountTagsMap(setStorageAccountTag(&existing, "cost-center", "5678"))
+	if len(output) != 2 || output["environment"] != "production" || output["cost-center"] != "5678" {
+		t.Fatalf("Expected the tag to be overwritten and the others left intact but got %+v", output)
+	}
+
+	if *existing["cost-center"] != "1234" {
+		t.Fatalf("Expected the existing tags not to be modified but got %q", *existing["cost-center"])
+	}
+
+	output = flattenStorageAccountTagsMap(setStorageAccountTag(nil, "cost-center", "1234"))
+	if len(output) != 1 || output["cost-center"] != "1234" {
+		t.Fatalf("Expected a single tag when there are no existing tags but got %+v", output)
+	}
+}
+
+func TestRemoveStorageAccountTag(t *testing.T) {
+	existing := map[string]*string{
+		"environment": utils.String("production"),
+		"cost-center": utils.String("1234"),
+	}
+
+	output := flattenStorageAccountTagsMap(removeStorageAccountTag(&existing, "cost-center"))
+	if len(output) != 1 || output["environment"] != "production" {
+		t.Fatalf("Expected only the specified tag to be removed but got %+v", output)
+	}
+
+	if len(existing) != 2 {
+		t.Fatalf("Expected the existing tags not to be modified but got %+v", existing)
+	}
+
+	if output := removeStorageAccountTag(nil, "cost-center"); output == nil || len(*output) != 0 {
+		t.Fatalf("Expected no tags when there are no existing tags but got %+v", output)
+	}
+}
+
+func TestValidateStorageAccountTagKey(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{"", 1},
+		{"cost-center", 0},
+		{"Cost Center", 0},
+		{"cost/center", 1},
+		{"cost?center", 1},
+		{"cost%center", 1},
+		{"<cost-center>", 1},
+		{acctest.RandString(512), 0},
+		{acctest.RandString(513), 1},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateStorageAccountTagKey(tc.Value, "key")
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected the Storage Account Tag Key %q to trigger '%d' errors - got '%d'", tc.Value, tc.ErrCount, len(errors))
+		}
+	}
+}
+
+func TestAccAzureRMStorageAccountTag_basic(t *testing.T) {
+	resourceName := "azurerm_storage_account_tag.test"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	location := testLocation()
+	config := testAccAzureRMStorageAccountTag_basic(ri, rs, location, "1234")
+	updatedConfig := testAccAzureRMStorageAccountTag_basic(ri, rs, location, "5678")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountTagValue(resourceName, "cost-center", "1234"),
+					testCheckAzureRMStorageAccountTagValue(resourceName, "environment", "production"),
+					resource.TestCheckResourceAttr(resourceName, "key", "cost-center"),
+					resource.TestCheckResourceAttr(resourceName, "value", "1234"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountTagValue(resourceName, "cost-center", "5678"),
+					testCheckAzureRMStorageAccountTagValue(resourceName, "environment", "production"),
+					resource.TestCheckResourceAttr(resourceName, "value", "5678"),
+				),
+			},
+		},
+	})
+}
+
+func testCheckAzureRMStorageAccountTagValue(name string, key string, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		id, err := parseAzureResourceID(rs.Primary.Attributes["storage_account_id"])
+		if err != nil {
+			return err
+		}
+		storageAccountName := id.Path["storageAccounts"]
+		resourceGroup := id.ResourceGroup
+
+		conn := testAccProvider.Meta().(*ArmClient).storageServiceClient
+		resp, err := conn.GetProperties(resourceGroup, storageAccountName)
+		if err != nil {
+			return fmt.Errorf("Bad: Get on storageServiceClient: %+v", err)
+		}
+
+		actual, exists := flattenStorageAccountTagsMap(resp.Tags)[key]
+		if !exists {
+			return fmt.Errorf("Bad: expected the tag %q to exist on Storage Account %q", key, storageAccountName)
+		}
+		if actual != value {
+			return fmt.Errorf("Bad: expected the tag %q to be %q but got %q", key, value, actual)
+		}
+
+		return nil
+	}
+}
+
+func testAccAzureRMStorageAccountTag_basic(rInt int, rString string, location string, value string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+    name = "testAccAzureRMSA-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.testrg.name}"
+    location = "${azurerm_resource_group.testrg.location}"
+    account_tier = "Standard"
+    account_replication_type = "LRS"
+    ignore_external_tags = true
+
+    tags {
+        environment = "production"
+    }
+}
+
+resource "azurerm_storage_account_tag" "test" {
+    storage_account_id = "${azurerm_storage_account.testsa.id}"
+    key = "cost-center"
+    value = "%s"
+}
+`, rInt, location, rString, value)
+}
@@ -3,11 +3,18 @@ package azurerm
 import (
 	"fmt"
 	"net/http"
+	"reflect"
+	"regexp"
 	"testing"
+	"time"
 
+	"github.com/Azure/azure-sdk-for-go/arm/storage"
+	mainStorage "github.com/Azure/azure-sdk-for-go/storage"
+	"github.com/Azure/go-autorest/autorest/date"
 	"github.com/hashicorp/terraform/helper/acctest"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
 func TestValidateArmStorageAccountType(t *testing.T) {
@@ -50,6 +57,651 @@ func TestValidateArmStorageAccountName(t *testing.T) {
 	}
 }
 
+func TestStorageAccountCreateMinTimeout(t *testing.T) {
+	testCases := []struct {
+		timeout  time.Duration
+		expected time.Duration
+	}{
+		{30 * time.Minute, 15 * time.Second},
+		{60 * time.Minute, 15 * time.Second},
+		{10 * time.Minute, 5 * time.Second},
+		{2 * time.Minute, time.Second},
+		{10 * time.Second, time.Second},
+	}
+
+	for _, test := range testCases {
+		if actual := storageAccountCreateMinTimeout(test.timeout); actual != test.expected {
+			t.Fatalf("Expected a timeout of %s to return %s but got %s", test.timeout, test.expected, actual)
+		}
+	}
+}
+
+func TestStorageAccountKindSupportsAccessTier(t *testing.T) {
+	testCases := []struct {
+		kind     string
+		expected bool
+	}{
+		{"BlobStorage", true},
+		{"blobstorage", true},
+		{"Storage", false},
+		{"", false},
+	}
+
+	for _, test := range testCases {
+		if actual := storageAccountKindSupportsAccessTier(test.kind); actual != test.expected {
+			t.Fatalf("Expected kind %q to return %t but got %t", test.kind, test.expected, actual)
+		}
+	}
+}
+
+func TestStorageAccountTierSupportsAccessTier(t *testing.T) {
+	testCases := []struct {
+		tier     string
+		expected bool
+	}{
+		{"Standard", true},
+		{"standard", true},
+		{"Premium", false},
+		{"premium", false},
+	}
+
+	for _, test := range testCases {
+		if actual := storageAccountTierSupportsAccessTier(test.tier); actual != test.expected {
+			t.Fatalf("Expected tier %q to return %t but got %t", test.tier, test.expected, actual)
+		}
+	}
+}
+
+func TestCheckStorageAccountProvisioningState(t *testing.T) {
+	testCases := []struct {
+		state            storage.ProvisioningState
+		expectedDeleting bool
+		expectedFailed   bool
+	}{
+		{storage.Succeeded, false, false},
+		{storage.Creating, false, false},
+		{storage.ResolvingDNS, false, false},
+		{storage.ProvisioningState("Deleting"), true, false},
+		{storage.ProvisioningState("deleting"), true, false},
+		{storage.ProvisioningState("Failed"), false, true},
+		{storage.ProvisioningState("failed"), false, true},
+	}
+
+	for _, test := range testCases {
+		deleting, failed := checkStorageAccountProvisioningState(test.state)
+		if deleting != test.expectedDeleting {
+			t.Fatalf("Expected state %q to return deleting %t but got %t", test.state, test.expectedDeleting, deleting)
+		}
+		if failed != test.expectedFailed {
+			t.Fatalf("Expected state %q to return failed %t but got %t", test.state, test.expectedFailed, failed)
+		}
+	}
+}
+
+func TestValidateStorageAccountHTTPSTrafficOnly(t *testing.T) {
+	testCases := []struct {
+		enforced               bool
+		enableHTTPSTrafficOnly bool
+		expectError            bool
+	}{
+		{false, false, false},
+		{false, true, false},
+		{true, false, true},
+		{true, true, false},
+	}
+
+	for _, test := range testCases {
+		err := validateStorageAccountHTTPSTrafficOnly(test.enforced, "example", test.enableHTTPSTrafficOnly)
+		if hasError := err != nil; hasError != test.expectError {
+			t.Fatalf("Expected enforced %t / enabled %t to return an error %t but got %+v", test.enforced, test.enableHTTPSTrafficOnly, test.expectError, err)
+		}
+	}
+}
+
+func TestBuildStorageAccountConnectionString(t *testing.T) {
+	testCases := []struct {
+		endpointSuffix string
+		endpoints      storage.Endpoints
+		expected       string
+	}{
+		{
+			endpointSuffix: "core.windows.net",
+			endpoints: storage.Endpoints{
+				Blob:  utils.String("https://example.blob.core.windows.net/"),
+				Queue: utils.String("https://example.queue.core.windows.net/"),
+				Table: utils.String("https://example.table.core.windows.net/"),
+				File:  utils.String("https://example.file.core.windows.net/"),
+			},
+			expected: "DefaultEndpointsProtocol=https;BlobEndpoint=https://example.blob.core.windows.net/;QueueEndpoint=https://example.queue.core.windows.net/;TableEndpoint=https://example.table.core.windows.net/;FileEndpoint=https://example.file.core.windows.net/;AccountName=example;AccountKey=secret;EndpointSuffix=core.windows.net",
+		},
+		{
+			endpointSuffix: "core.windows.net",
+			endpoints: storage.Endpoints{
+				Blob: utils.String("https://example.blob.core.windows.net/"),
+			},
+			expected: "DefaultEndpointsProtocol=https;BlobEndpoint=https://example.blob.core.windows.net/;AccountName=example;AccountKey=secret;EndpointSuffix=core.windows.net",
+		},
+		{
+			endpointSuffix: "core.chinacloudapi.cn",
+			endpoints: storage.Endpoints{
+				Blob: utils.String("https://example.blob.core.chinacloudapi.cn/"),
+			},
+			expected: "DefaultEndpointsProtocol=https;BlobEndpoint=https://example.blob.core.chinacloudapi.cn/;AccountName=example;AccountKey=secret;EndpointSuffix=core.chinacloudapi.cn",
+		},
+	}
+
+	for _, test := range testCases {
+		actual := buildStorageAccountConnectionString("example", "secret", test.endpointSuffix, &test.endpoints)
+		if actual != test.expected {
+			t.Fatalf("Expected the Connection String to be %q but got %q", test.expected, actual)
+		}
+	}
+}
+
+func TestFlattenStorageAccountEndpoints(t *testing.T) {
+	emptyEndpoints := flattenStorageAccountEndpoints("example", "key", "core.windows.net", nil)
+	if emptyEndpoints != (storageAccountEndpoints{}) {
+		t.Fatalf("Expected no endpoints to be returned when none are specified but got %+v", emptyEndpoints)
+	}
+
+	endpoints := flattenStorageAccountEndpoints("example", "key", "core.windows.net", &storage.Endpoints{
+		Blob:  utils.String("https://example-secondary.blob.core.windows.net/"),
+		Queue: utils.String("https://example-secondary.queue.core.windows.net/"),
+	})
+	expected := storageAccountEndpoints{
+		blobEndpoint:         "https://example-secondary.blob.core.windows.net/",
+		blobHost:             "example-secondary.blob.core.windows.net",
+		queueEndpoint:        "https://example-secondary.queue.core.windows.net/",
+		tableEndpoint:        "",
+		fileEndpoint:         "",
+		connectionString:     "DefaultEndpointsProtocol=https;BlobEndpoint=https://example-secondary.blob.core.windows.net/;QueueEndpoint=https://example-secondary.queue.core.windows.net/;AccountName=example;AccountKey=key;EndpointSuffix=core.windows.net",
+		blobConnectionString: "DefaultEndpointsProtocol=https;BlobEndpoint=https://example-secondary.blob.core.windows.net/;AccountName=example;AccountKey=key;EndpointSuffix=core.windows.net",
+	}
+	if endpoints != expected {
+		t.Fatalf("Expected %+v but got %+v", expected, endpoints)
+	}
+
+	noKeyEndpoints := flattenStorageAccountEndpoints("example", "", "core.windows.net", &storage.Endpoints{
+		Blob: utils.String("https://example.blob.core.windows.net/"),
+	})
+	if noKeyEndpoints.blobEndpoint != "https://example.blob.core.windows.net/" {
+		t.Fatalf("Expected the blob endpoint to be set without an access key but got %q", noKeyEndpoints.blobEndpoint)
+	}
+	if noKeyEndpoints.connectionString != "" || noKeyEndpoints.blobConnectionString != "" {
+		t.Fatalf("Expected no connection strings without an access key but got %+v", noKeyEndpoints)
+	}
+
+	fileEndpoints := flattenStorageAccountEndpoints("example", "key", "core.windows.net", &storage.Endpoints{
+		File: utils.String("https://example-secondary.file.core.windows.net/"),
+	})
+	expectedFileConnectionString := "DefaultEndpointsProtocol=https;FileEndpoint=https://example-secondary.file.core.windows.net/;AccountName=example;AccountKey=key;EndpointSuffix=core.windows.net"
+	if fileEndpoints.fileEndpoint != "https://example-secondary.file.core.windows.net/" {
+		t.Fatalf("Expected the file endpoint to be set but got %q", fileEndpoints.fileEndpoint)
+	}
+	if fileEndpoints.fileConnectionString != expectedFileConnectionString {
+		t.Fatalf("Expected the file connection string to be %q but got %q", expectedFileConnectionString, fileEndpoints.fileConnectionString)
+	}
+	if fileEndpoints.blobConnectionString != "" {
+		t.Fatalf("Expected no blob connection string when no blob endpoint is returned but got %q", fileEndpoints.blobConnectionString)
+	}
+}
+
+func TestValidateStorageAccountSku(t *testing.T) {
+	testCases := []struct {
+		kind            string
+		tier            string
+		replicationType string
+		valid           bool
+	}{
+		{"Storage", "Standard", "LRS", true},
+		{"Storage", "Standard", "GRS", true},
+		{"Storage", "Standard", "RAGRS", true},
+		{"Storage", "Standard", "ZRS", true},
+		{"Storage", "Premium", "LRS", true},
+		{"Storage", "premium", "lrs", true},
+		{"Storage", "Premium", "GRS", false},
+		{"Storage", "Premium", "ZRS", false},
+		{"BlobStorage", "Standard", "LRS", true},
+		{"BlobStorage", "Standard", "RAGRS", true},
+		{"BlobStorage", "Standard", "ZRS", false},
+		{"BlobStorage", "Premium", "LRS", false},
+	}
+
+	for _, test := range testCases {
+		err := validateStorageAccountSku(test.kind, test.tier, test.replicationType)
+		if valid := err == nil; valid != test.valid {
+			t.Fatalf("Expected the combination %q / %q / %q to be valid %t but got %t (%+v)", test.kind, test.tier, test.replicationType, test.valid, valid, err)
+		}
+	}
+}
+
+func TestFlattenStorageAccountCreationTime(t *testing.T) {
+	if actual := flattenStorageAccountCreationTime(nil); actual != "" {
+		t.Fatalf("Expected an empty Creation Time when none is returned but got %q", actual)
+	}
+
+	testCases := []struct {
+		input    time.Time
+		expected string
+	}{
+		{time.Date(2017, 10, 6, 12, 34, 56, 789000000, time.UTC), "2017-10-06T12:34:56Z"},
+		{time.Date(2017, 10, 6, 14, 34, 56, 0, time.FixedZone("UTC+2", 2*60*60)), "2017-10-06T12:34:56Z"},
+	}
+
+	for _, test := range testCases {
+		if actual := flattenStorageAccountCreationTime(&date.Time{Time: test.input}); actual != test.expected {
+			t.Fatalf("Expected the Creation Time %s to be formatted as %q but got %q", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestParseStorageAccountReplicationType(t *testing.T) {
+	testCases := []struct {
+		skuName     string
+		expected    string
+		shouldError bool
+	}{
+		{"Standard_LRS", "LRS", false},
+		{"Standard_RAGRS", "RAGRS", false},
+		{"Premium_LRS", "LRS", false},
+		{"Standard_GZRS_Preview", "GZRS_Preview", false},
+		{"Standard", "", true},
+		{"", "", true},
+		{"_LRS", "", true},
+		{"Standard_", "", true},
+	}
+
+	for _, test := range testCases {
+		actual, err := parseStorageAccountReplicationType(test.skuName)
+		if test.shouldError {
+			if err == nil {
+				t.Fatalf("Expected an error parsing the SKU Name %q but didn't get one", test.skuName)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Expected no error parsing the SKU Name %q but got: %+v", test.skuName, err)
+		}
+
+		if actual != test.expected {
+			t.Fatalf("Expected the Replication Type for %q to be %q but got %q", test.skuName, test.expected, actual)
+		}
+	}
+}
+
+func TestStorageAccountReplicationChangeRequiresRecreation(t *testing.T) {
+	testCases := []struct {
+		old      string
+		new      string
+		expected bool
+	}{
+		{"LRS", "GRS", false},
+		{"GRS", "RAGRS", false},
+		{"RAGRS", "LRS", false},
+		{"ZRS", "zrs", false},
+		{"LRS", "ZRS", true},
+		{"ZRS", "LRS", true},
+		{"zrs", "GRS", true},
+		{"RAGRS", "ZRS", true},
+	}
+
+	for _, test := range testCases {
+		if actual := storageAccountReplicationChangeRequiresRecreation(test.old, test.new); actual != test.expected {
+			t.Fatalf("Expected changing from %q to %q to return %t but got %t", test.old, test.new, test.expected, actual)
+		}
+	}
+}
+
+func TestExpandStorageAccountCustomDomain(t *testing.T) {
+	testCases := []struct {
+		input        []interface{}
+		name         *string
+		useSubDomain *bool
+	}{
+		{
+			input: []interface{}{},
+		},
+		{
+			input: []interface{}{nil},
+		},
+		{
+			input: []interface{}{
+				map[string]interface{}{
+					"name":          "www.example.com",
+					"use_subdomain": true,
+				},
+			},
+			name:         utils.String("www.example.com"),
+			useSubDomain: utils.Bool(true),
+		},
+	}
+
+	for _, tc := range testCases {
+		output := expandStorageAccountCustomDomain(tc.input)
+
+		if tc.name == nil {
+			if output != nil {
+				t.Fatalf("Expected no Custom Domain for %+v but got %+v", tc.input, output)
+			}
+			continue
+		}
+
+		if output == nil {
+			t.Fatalf("Expected a Custom Domain for %+v but got nil", tc.input)
+		}
+
+		if *output.Name != *tc.name {
+			t.Fatalf("Expected the name to be %q but got %q", *tc.name, *output.Name)
+		}
+
+		if *output.UseSubDomain != *tc.useSubDomain {
+			t.Fatalf("Expected use_subdomain to be %t but got %t", *tc.useSubDomain, *output.UseSubDomain)
+		}
+	}
+}
+
+func TestFlattenStorageAccountCustomDomain(t *testing.T) {
+	testCases := []struct {
+		input    *storage.CustomDomain
+		expected []interface{}
+	}{
+		{
+			input:    nil,
+			expected: []interface{}{},
+		},
+		{
+			input:    &storage.CustomDomain{},
+			expected: []interface{}{},
+		},
+		{
+			input: &storage.CustomDomain{
+				Name: utils.String(""),
+			},
+			expected: []interface{}{},
+		},
+		{
+			input: &storage.CustomDomain{
+				Name: utils.String("www.example.com"),
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"name": "www.example.com",
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		output := flattenStorageAccountCustomDomain(tc.input)
+		if !reflect.DeepEqual(output, tc.expected) {
+			t.Fatalf("Expected %+v but got %+v", tc.expected, output)
+		}
+	}
+}
+
+func TestExpandStorageAccountMetrics(t *testing.T) {
+	testCases := []struct {
+		input               []interface{}
+		enabled             bool
+		includeAPIs         *bool
+		retentionEnabled    bool
+		retentionPolicyDays *int
+	}{
+		{
+			input:            []interface{}{},
+			enabled:          false,
+			includeAPIs:      nil,
+			retentionEnabled: false,
+		},
+		{
+			input: []interface{}{
+				map[string]interface{}{
+					"enabled":               true,
+					"include_apis":          true,
+					"retention_policy_days": 7,
+				},
+			},
+			enabled:             true,
+			includeAPIs:         utils.Bool(true),
+			retentionEnabled:    true,
+			retentionPolicyDays: utils.Int(7),
+		},
+		{
+			input: []interface{}{
+				map[string]interface{}{
+					"enabled":               true,
+					"include_apis":          false,
+					"retention_policy_days": 0,
+				},
+			},
+			enabled:          true,
+			includeAPIs:      utils.Bool(false),
+			retentionEnabled: false,
+		},
+		{
+			input: []interface{}{
+				map[string]interface{}{
+					"enabled":               false,
+					"include_apis":          true,
+					"retention_policy_days": 0,
+				},
+			},
+			enabled:          false,
+			includeAPIs:      nil,
+			retentionEnabled: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		metrics := expandStorageAccountMetrics(tc.input)
+
+		if metrics.Version != storageAccountMetricsVersion {
+			t.Fatalf("Expected the Version to be %q but got %q", storageAccountMetricsVersion, metrics.Version)
+		}
+
+		if metrics.Enabled != tc.enabled {
+			t.Fatalf("Expected Enabled to be %t but got %t for %+v", tc.enabled, metrics.Enabled, tc.input)
+		}
+
+		if (tc.includeAPIs == nil) != (metrics.IncludeAPIs == nil) || (tc.includeAPIs != nil && *tc.includeAPIs != *metrics.IncludeAPIs) {
+			t.Fatalf("Expected IncludeAPIs to be %+v but got %+v for %+v", tc.includeAPIs, metrics.IncludeAPIs, tc.input)
+		}
+
+		if metrics.RetentionPolicy.Enabled != tc.retentionEnabled {
+			t.Fatalf("Expected the Retention Policy to be enabled %t but got %t for %+v", tc.retentionEnabled, metrics.RetentionPolicy.Enabled, tc.input)
+		}
+
+		if tc.retentionPolicyDays != nil && *metrics.RetentionPolicy.Days != *tc.retentionPolicyDays {
+			t.Fatalf("Expected the Retention Policy Days to be %d but got %d", *tc.retentionPolicyDays, *metrics.RetentionPolicy.Days)
+		}
+	}
+}
+
+func TestFlattenStorageAccountMetrics(t *testing.T) {
+	testCases := []struct {
+		input    *mainStorage.Metrics
+		expected []interface{}
+	}{
+		{
+			input:    nil,
+			expected: []interface{}{},
+		},
+		{
+			input: &mainStorage.Metrics{
+				Enabled: false,
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"enabled":               false,
+					"include_apis":          false,
+					"retention_policy_days": 0,
+				},
+			},
+		},
+		{
+			input: &mainStorage.Metrics{
+				Enabled:     true,
+				IncludeAPIs: utils.Bool(true),
+				RetentionPolicy: &mainStorage.RetentionPolicy{
+					Enabled: true,
+					Days:    utils.Int(30),
+				},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"enabled":               true,
+					"include_apis":          true,
+					"retention_policy_days": 30,
+				},
+			},
+		},
+		{
+			input: &mainStorage.Metrics{
+				Enabled:     true,
+				IncludeAPIs: utils.Bool(false),
+				RetentionPolicy: &mainStorage.RetentionPolicy{
+					Enabled: false,
+				},
+			},
+			expected: []interface{}{
+				map[string]interface{}{
+					"enabled":               true,
+					"include_apis":          false,
+					"retention_policy_days": 0,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		output := flattenStorageAccountMetrics(tc.input)
+		if !reflect.DeepEqual(output, tc.expected) {
+			t.Fatalf("Expected %+v but got %+v", tc.expected, output)
+		}
+	}
+}
+
+func TestStorageAccountReplicationChangeIsDowngrade(t *testing.T) {
+	testCases := []struct {
+		old      string
+		new      string
+		expected bool
+	}{
+		{"LRS", "GRS", false},
+		{"GRS", "RAGRS", false},
+		{"LRS", "RAGRS", false},
+		{"GRS", "grs", false},
+		{"RAGRS", "GRS", true},
+		{"RAGRS", "LRS", true},
+		{"ragrs", "lrs", true},
+		{"GRS", "LRS", true},
+		{"GRS", "ZRS", true},
+		{"", "LRS", false},
+	}
+
+	for _, test := range testCases {
+		if actual := storageAccountReplicationChangeIsDowngrade(test.old, test.new); actual != test.expected {
+			t.Fatalf("Expected changing from %q to %q to return %t but got %t", test.old, test.new, test.expected, actual)
+		}
+	}
+}
+
+func TestMergeStorageAccountExternalTags(t *testing.T) {
+	existing := map[string]*string{
+		"environment": utils.String("Production"),
+		"cost-center": utils.String("12345"),
+		"policy":      utils.String("assigned"),
+	}
+	oldTags := map[string]interface{}{
+		"environment": "Production",
+		"cost-center": "12345",
+	}
+	newTags := map[string]interface{}{
+		"environment": "Staging",
+		"owner":       "platform",
+	}
+
+	expected := map[string]string{
+		"environment": "Staging",
+		"owner":       "platform",
+		"policy":      "assigned",
+	}
+
+	actual := flattenStorageAccountTagsForTest(mergeStorageAccountExternalTags(&existing, oldTags, newTags))
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected the merged tags to be %+v but got %+v", expected, actual)
+	}
+}
+
+func TestFilterStorageAccountManagedTags(t *testing.T) {
+	tags := map[string]*string{
+		"environment": utils.String("Production"),
+		"policy":      utils.String("assigned"),
+	}
+	managedTags := map[string]interface{}{
+		"environment": "Production",
+		"owner":       "platform",
+	}
+
+	expected := map[string]string{
+		"environment": "Production",
+	}
+
+	actual := flattenStorageAccountTagsForTest(filterStorageAccountManagedTags(&tags, managedTags))
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected the filtered tags to be %+v but got %+v", expected, actual)
+	}
+
+	if actual := filterStorageAccountManagedTags(nil, managedTags); len(*actual) != 0 {
+		t.Fatalf("Expected no tags when the Storage Account has no tags but got %+v", *actual)
+	}
+}
+
+func flattenStorageAccountTagsForTest(input *map[string]*string) map[string]string {
+	output := make(map[string]string)
+	for k, v := range *input {
+		output[k] = *v
+	}
+	return output
+}
+
+func TestValidateStorageAccountMove(t *testing.T) {
+	testCases := []struct {
+		id             string
+		subscriptionId string
+		valid          bool
+	}{
+		{
+			id:             "/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/account1",
+			subscriptionId: "00000000-0000-0000-0000-000000000000",
+			valid:          true,
+		},
+		{
+			id:             "/subscriptions/AAAAAAAA-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/account1",
+			subscriptionId: "aaaaaaaa-0000-0000-0000-000000000000",
+			valid:          true,
+		},
+		{
+			id:             "/subscriptions/11111111-0000-0000-0000-000000000000/resourceGroups/example/providers/Microsoft.Storage/storageAccounts/account1",
+			subscriptionId: "00000000-0000-0000-0000-000000000000",
+			valid:          false,
+		},
+	}
+
+	for _, test := range testCases {
+		id, err := parseAzureResourceID(test.id)
+		if err != nil {
+			t.Fatalf("Error parsing %q: %+v", test.id, err)
+		}
+
+		err = validateStorageAccountMove(id, test.subscriptionId)
+		if valid := err == nil; valid != test.valid {
+			t.Fatalf("Expected moving %q within Subscription %q to be valid %t but got %t", test.id, test.subscriptionId, test.valid, valid)
+		}
+	}
+}
+
 func TestAccAzureRMStorageAccount_basic(t *testing.T) {
 	resourceName := "azurerm_storage_account.testsa"
 	ri := acctest.RandInt()
@@ -71,6 +723,10 @@ func TestAccAzureRMStorageAccount_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "account_replication_type", "LRS"),
 					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
 					resource.TestCheckResourceAttr(resourceName, "tags.environment", "production"),
+					resource.TestCheckResourceAttr(resourceName, "provisioning_state", "Succeeded"),
+					resource.TestCheckResourceAttrSet(resourceName, "creation_time"),
+					resource.TestCheckResourceAttr(resourceName, "status_of_primary", "available"),
+					resource.TestCheckResourceAttr(resourceName, "status_of_secondary", ""),
 				),
 			},
 
@@ -82,6 +738,7 @@ func TestAccAzureRMStorageAccount_basic(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "account_replication_type", "GRS"),
 					resource.TestCheckResourceAttr(resourceName, "tags.%", "1"),
 					resource.TestCheckResourceAttr(resourceName, "tags.environment", "staging"),
+					resource.TestCheckResourceAttrSet(resourceName, "status_of_secondary"),
 				),
 			},
 		},
@@ -114,6 +771,60 @@ func TestAccAzureRMStorageAccount_premium(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMStorageAccount_emptyTagValue(t *testing.T) {
+	resourceName := "azurerm_storage_account.testsa"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	config := testAccAzureRMStorageAccount_emptyTagValue(ri, rs, testLocation())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists(resourceName),
+					resource.TestCheckResourceAttr(resourceName, "tags.%", "2"),
+					resource.TestCheckResourceAttr(resourceName, "tags.environment", "production"),
+					resource.TestCheckResourceAttr(resourceName, "tags.owner", ""),
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageAccount_skipKeyRetrieval(t *testing.T) {
+	resourceName := "azurerm_storage_account.testsa"
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	config := testAccAzureRMStorageAccount_skipKeyRetrieval(ri, rs, testLocation())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists(resourceName),
+					resource.TestCheckResourceAttrSet(resourceName, "primary_blob_endpoint"),
+					resource.TestCheckResourceAttr(resourceName, "primary_access_key", ""),
+					resource.TestCheckResourceAttr(resourceName, "secondary_access_key", ""),
+					resource.TestCheckResourceAttr(resourceName, "primary_connection_string", ""),
+					resource.TestCheckResourceAttr(resourceName, "primary_blob_connection_string", ""),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAzureRMStorageAccount_disappears(t *testing.T) {
 	resourceName := "azurerm_storage_account.testsa"
 	ri := acctest.RandInt()
@@ -135,16 +846,161 @@ func TestAccAzureRMStorageAccount_disappears(t *testing.T) {
 					resource.TestCheckResourceAttr(resourceName, "tags.environment", "production"),
 					testCheckAzureRMStorageAccountDisappears(resourceName),
 				),
-				ExpectNonEmptyPlan: true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageAccount_blobConnectionString(t *testing.T) {
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	preConfig := testAccAzureRMStorageAccount_basic(ri, rs, testLocation())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: preConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
+					resource.TestCheckResourceAttrSet("azurerm_storage_account.testsa", "primary_blob_connection_string"),
+					resource.TestCheckResourceAttrSet("azurerm_storage_account.testsa", "primary_connection_string"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageAccount_blobEncryption(t *testing.T) {
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	location := testLocation()
+	preConfig := testAccAzureRMStorageAccount_blobEncryption(ri, rs, location)
+	postConfig := testAccAzureRMStorageAccount_blobEncryptionDisabled(ri, rs, location)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: preConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "enable_blob_encryption", "true"),
+				),
+			},
+
+			{
+				Config: postConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "enable_blob_encryption", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageAccount_fileEncryption(t *testing.T) {
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	location := testLocation()
+	preConfig := testAccAzureRMStorageAccount_fileEncryption(ri, rs, location)
+	postConfig := testAccAzureRMStorageAccount_fileEncryptionDisabled(ri, rs, location)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: preConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "enable_file_encryption", "true"),
+				),
+			},
+
+			{
+				Config: postConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "enable_file_encryption", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageAccount_enableHttpsTrafficOnly(t *testing.T) {
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	location := testLocation()
+	preConfig := testAccAzureRMStorageAccount_enableHttpsTrafficOnly(ri, rs, location)
+	postConfig := testAccAzureRMStorageAccount_enableHttpsTrafficOnlyDisabled(ri, rs, location)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: preConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "enable_https_traffic_only", "true"),
+				),
+			},
+
+			{
+				Config: postConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "enable_https_traffic_only", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageAccount_replicationToZRS(t *testing.T) {
+	ri := acctest.RandInt()
+	rs := acctest.RandString(4)
+	location := testLocation()
+	preConfig := testAccAzureRMStorageAccount_basic(ri, rs, location)
+	zrsConfig := testAccAzureRMStorageAccount_replicationToZRS(ri, rs, location)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageAccountDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: preConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "account_replication_type", "LRS"),
+				),
+			},
+			{
+				Config:      zrsConfig,
+				ExpectError: regexp.MustCompile("isn't supported in-place"),
 			},
 		},
 	})
 }
 
-func TestAccAzureRMStorageAccount_blobConnectionString(t *testing.T) {
+func TestAccAzureRMStorageAccount_replicationDowngrade(t *testing.T) {
 	ri := acctest.RandInt()
 	rs := acctest.RandString(4)
-	preConfig := testAccAzureRMStorageAccount_basic(ri, rs, testLocation())
+	location := testLocation()
+	preConfig := testAccAzureRMStorageAccount_update(ri, rs, location)
+	downgradeConfig := testAccAzureRMStorageAccount_replicationDowngrade(ri, rs, location, false)
+	allowedConfig := testAccAzureRMStorageAccount_replicationDowngrade(ri, rs, location, true)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -155,19 +1011,30 @@ func TestAccAzureRMStorageAccount_blobConnectionString(t *testing.T) {
 				Config: preConfig,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
-					resource.TestCheckResourceAttrSet("azurerm_storage_account.testsa", "primary_blob_connection_string"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "account_replication_type", "GRS"),
+				),
+			},
+			{
+				Config:      downgradeConfig,
+				ExpectError: regexp.MustCompile("reduces the redundancy"),
+			},
+			{
+				Config: allowedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "account_replication_type", "LRS"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAzureRMStorageAccount_blobEncryption(t *testing.T) {
+func TestAccAzureRMStorageAccount_moveResourceGroup(t *testing.T) {
 	ri := acctest.RandInt()
 	rs := acctest.RandString(4)
 	location := testLocation()
-	preConfig := testAccAzureRMStorageAccount_blobEncryption(ri, rs, location)
-	postConfig := testAccAzureRMStorageAccount_blobEncryptionDisabled(ri, rs, location)
+	preConfig := testAccAzureRMStorageAccount_moveResourceGroup(ri, rs, location, "first")
+	postConfig := testAccAzureRMStorageAccount_moveResourceGroup(ri, rs, location, "second")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -178,27 +1045,26 @@ func TestAccAzureRMStorageAccount_blobEncryption(t *testing.T) {
 				Config: preConfig,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
-					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "enable_blob_encryption", "true"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "resource_group_name", fmt.Sprintf("testAccAzureRMSA-%d-first", ri)),
 				),
 			},
-
 			{
 				Config: postConfig,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
-					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "enable_blob_encryption", "false"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "resource_group_name", fmt.Sprintf("testAccAzureRMSA-%d-second", ri)),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAzureRMStorageAccount_fileEncryption(t *testing.T) {
+func TestAccAzureRMStorageAccount_blobProperties(t *testing.T) {
 	ri := acctest.RandInt()
 	rs := acctest.RandString(4)
 	location := testLocation()
-	preConfig := testAccAzureRMStorageAccount_fileEncryption(ri, rs, location)
-	postConfig := testAccAzureRMStorageAccount_fileEncryptionDisabled(ri, rs, location)
+	preConfig := testAccAzureRMStorageAccount_blobProperties(ri, rs, location)
+	postConfig := testAccAzureRMStorageAccount_blobPropertiesUpdated(ri, rs, location)
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -209,7 +1075,10 @@ func TestAccAzureRMStorageAccount_fileEncryption(t *testing.T) {
 				Config: preConfig,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
-					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "enable_file_encryption", "true"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "blob_properties.0.hour_metrics.0.enabled", "true"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "blob_properties.0.hour_metrics.0.include_apis", "true"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "blob_properties.0.hour_metrics.0.retention_policy_days", "7"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "blob_properties.0.minute_metrics.0.enabled", "false"),
 				),
 			},
 
@@ -217,19 +1086,24 @@ func TestAccAzureRMStorageAccount_fileEncryption(t *testing.T) {
 				Config: postConfig,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
-					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "enable_file_encryption", "false"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "blob_properties.0.hour_metrics.0.enabled", "true"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "blob_properties.0.hour_metrics.0.include_apis", "false"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "blob_properties.0.hour_metrics.0.retention_policy_days", "30"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "blob_properties.0.minute_metrics.0.enabled", "true"),
+					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "blob_properties.0.minute_metrics.0.retention_policy_days", "1"),
 				),
 			},
 		},
 	})
 }
 
-func TestAccAzureRMStorageAccount_enableHttpsTrafficOnly(t *testing.T) {
+func TestAccAzureRMStorageAccount_keyRotation(t *testing.T) {
+	var primaryAccessKey, secondaryAccessKey string
 	ri := acctest.RandInt()
 	rs := acctest.RandString(4)
 	location := testLocation()
-	preConfig := testAccAzureRMStorageAccount_enableHttpsTrafficOnly(ri, rs, location)
-	postConfig := testAccAzureRMStorageAccount_enableHttpsTrafficOnlyDisabled(ri, rs, location)
+	preConfig := testAccAzureRMStorageAccount_keyRotation(ri, rs, location, "first", "first")
+	postConfig := testAccAzureRMStorageAccount_keyRotation(ri, rs, location, "second", "first")
 
 	resource.Test(t, resource.TestCase{
 		PreCheck:     func() { testAccPreCheck(t) },
@@ -240,7 +1114,8 @@ func TestAccAzureRMStorageAccount_enableHttpsTrafficOnly(t *testing.T) {
 				Config: preConfig,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
-					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "enable_https_traffic_only", "true"),
+					testCheckAzureRMStorageAccountAttrValue("azurerm_storage_account.testsa", "primary_access_key", &primaryAccessKey),
+					testCheckAzureRMStorageAccountAttrValue("azurerm_storage_account.testsa", "secondary_access_key", &secondaryAccessKey),
 				),
 			},
 
@@ -248,7 +1123,9 @@ func TestAccAzureRMStorageAccount_enableHttpsTrafficOnly(t *testing.T) {
 				Config: postConfig,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMStorageAccountExists("azurerm_storage_account.testsa"),
-					resource.TestCheckResourceAttr("azurerm_storage_account.testsa", "enable_https_traffic_only", "false"),
+					testCheckAzureRMStorageAccountAttrChanged("azurerm_storage_account.testsa", "primary_access_key", &primaryAccessKey, true),
+					testCheckAzureRMStorageAccountAttrChanged("azurerm_storage_account.testsa", "secondary_access_key", &secondaryAccessKey, false),
+					testCheckAzureRMStorageAccountAccessKeysMatch("azurerm_storage_account.testsa"),
 				),
 			},
 		},
@@ -313,6 +1190,66 @@ func TestAccAzureRMStorageAccount_NonStandardCasing(t *testing.T) {
 	})
 }
 
+func testCheckAzureRMStorageAccountAttrValue(name string, key string, value *string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		*value = rs.Primary.Attributes[key]
+		return nil
+	}
+}
+
+func testCheckAzureRMStorageAccountAttrChanged(name string, key string, previous *string, shouldChange bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		if changed := rs.Primary.Attributes[key] != *previous; changed != shouldChange {
+			return fmt.Errorf("Bad: expected %q to have changed %t but got %t", key, shouldChange, changed)
+		}
+
+		return nil
+	}
+}
+
+// testCheckAzureRMStorageAccountAccessKeysMatch ensures the Access Keys in the State are those currently
+// returned from the API, such that a regenerated key has actually been exported
+func testCheckAzureRMStorageAccountAccessKeysMatch(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("Not found: %s", name)
+		}
+
+		storageAccount := rs.Primary.Attributes["name"]
+		resourceGroup := rs.Primary.Attributes["resource_group_name"]
+
+		conn := testAccProvider.Meta().(*ArmClient).storageServiceClient
+		keys, err := conn.ListKeys(resourceGroup, storageAccount)
+		if err != nil {
+			return fmt.Errorf("Bad: ListKeys on storageServiceClient: %+v", err)
+		}
+
+		if keys.Keys == nil || len(*keys.Keys) < 2 {
+			return fmt.Errorf("Bad: expected 2 Access Keys for Storage Account %q but got %+v", storageAccount, keys.Keys)
+		}
+
+		for i, attr := range []string{"primary_access_key", "secondary_access_key"} {
+			value := (*keys.Keys)[i].Value
+			if value == nil || *value != rs.Primary.Attributes[attr] {
+				return fmt.Errorf("Bad: expected %q to match the Access Key returned from the API", attr)
+			}
+		}
+
+		return nil
+	}
+}
+
 func testCheckAzureRMStorageAccountExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		// Ensure we have enough information in state to look up in API
@@ -409,6 +1346,71 @@ resource "azurerm_storage_account" "testsa" {
 `, rInt, location, rString)
 }
 
+func testAccAzureRMStorageAccount_ignoreExternalTags(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+    name = "testAccAzureRMSA-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.testrg.name}"
+
+    location = "${azurerm_resource_group.testrg.location}"
+    account_tier = "Standard"
+    account_replication_type = "LRS"
+    ignore_external_tags = true
+
+    tags {
+        environment = "production"
+    }
+}
+`, rInt, location, rString)
+}
+
+func testAccAzureRMStorageAccount_emptyTagValue(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+    name = "testAccAzureRMSA-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.testrg.name}"
+
+    location = "${azurerm_resource_group.testrg.location}"
+    account_tier = "Standard"
+    account_replication_type = "LRS"
+
+    tags {
+        environment = "production"
+        owner = ""
+    }
+}
+`, rInt, location, rString)
+}
+
+func testAccAzureRMStorageAccount_skipKeyRetrieval(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+    name = "testAccAzureRMSA-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.testrg.name}"
+
+    location = "${azurerm_resource_group.testrg.location}"
+    account_tier = "Standard"
+    account_replication_type = "LRS"
+    skip_key_retrieval = true
+}
+`, rInt, location, rString)
+}
+
 func testAccAzureRMStorageAccount_premium(rInt int, rString string, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "testrg" {
@@ -453,6 +1455,78 @@ resource "azurerm_storage_account" "testsa" {
 `, rInt, location, rString)
 }
 
+func testAccAzureRMStorageAccount_replicationDowngrade(rInt int, rString string, location string, allowDowngrade bool) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+    name = "testAccAzureRMSA-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.testrg.name}"
+
+    location = "${azurerm_resource_group.testrg.location}"
+    account_tier = "Standard"
+    account_replication_type = "LRS"
+    allow_replication_downgrade = %t
+
+    tags {
+        environment = "staging"
+    }
+}
+`, rInt, location, rString, allowDowngrade)
+}
+
+func testAccAzureRMStorageAccount_replicationToZRS(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+    name = "testAccAzureRMSA-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.testrg.name}"
+
+    location = "${azurerm_resource_group.testrg.location}"
+    account_tier = "Standard"
+    account_replication_type = "ZRS"
+
+    tags {
+        environment = "staging"
+    }
+}
+`, rInt, location, rString)
+}
+
+func testAccAzureRMStorageAccount_moveResourceGroup(rInt int, rString string, location string, resourceGroup string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "first" {
+    name = "testAccAzureRMSA-%d-first"
+    location = "%s"
+}
+
+resource "azurerm_resource_group" "second" {
+    name = "testAccAzureRMSA-%d-second"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.%s.name}"
+
+    location = "${azurerm_resource_group.first.location}"
+    account_tier = "Standard"
+    account_replication_type = "LRS"
+
+    tags {
+        environment = "production"
+    }
+}
+`, rInt, location, rInt, location, rString, resourceGroup)
+}
+
 func testAccAzureRMStorageAccount_blobEncryption(rInt int, rString string, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "testrg" {
@@ -591,6 +1665,70 @@ resource "azurerm_storage_account" "testsa" {
 `, rInt, location, rString)
 }
 
+func testAccAzureRMStorageAccount_blobProperties(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+    name = "testAccAzureRMSA-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.testrg.name}"
+
+    location = "${azurerm_resource_group.testrg.location}"
+    account_tier = "Standard"
+    account_replication_type = "LRS"
+
+    blob_properties {
+        hour_metrics {
+            enabled = true
+            include_apis = true
+            retention_policy_days = 7
+        }
+    }
+
+    tags {
+        environment = "production"
+    }
+}
+`, rInt, location, rString)
+}
+
+func testAccAzureRMStorageAccount_blobPropertiesUpdated(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+    name = "testAccAzureRMSA-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.testrg.name}"
+
+    location = "${azurerm_resource_group.testrg.location}"
+    account_tier = "Standard"
+    account_replication_type = "LRS"
+
+    blob_properties {
+        hour_metrics {
+            enabled = true
+            retention_policy_days = 30
+        }
+
+        minute_metrics {
+            enabled = true
+            retention_policy_days = 1
+        }
+    }
+
+    tags {
+        environment = "production"
+    }
+}
+`, rInt, location, rString)
+}
+
 func testAccAzureRMStorageAccount_blobStorage(rInt int, rString string, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "testrg" {
@@ -656,3 +1794,24 @@ resource "azurerm_storage_account" "testsa" {
 }
 `, rInt, location, rString)
 }
+
+func testAccAzureRMStorageAccount_keyRotation(rInt int, rString string, location string, primaryTrigger string, secondaryTrigger string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "testrg" {
+    name = "testAccAzureRMSA-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "testsa" {
+    name = "unlikely23exst2acct%s"
+    resource_group_name = "${azurerm_resource_group.testrg.name}"
+
+    location = "${azurerm_resource_group.testrg.location}"
+    account_tier = "Standard"
+    account_replication_type = "LRS"
+
+    primary_access_key_rotation_trigger = "%s"
+    secondary_access_key_rotation_trigger = "%s"
+}
+`, rInt, location, rString, primaryTrigger, secondaryTrigger)
+}
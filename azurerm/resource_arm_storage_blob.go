@@ -2,6 +2,7 @@ package azurerm
 
 import (
 	"bytes"
+	"crypto/md5"
 	"crypto/rand"
 	"encoding/base64"
 	"fmt"
@@ -20,6 +21,7 @@ func resourceArmStorageBlob() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmStorageBlobCreate,
 		Read:   resourceArmStorageBlobRead,
+		Update: resourceArmStorageBlobUpdate,
 		Exists: resourceArmStorageBlobExists,
 		Delete: resourceArmStorageBlobDelete,
 
@@ -35,6 +37,7 @@ func resourceArmStorageBlob() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"storage_account_key": storageAccountKeySchema(),
 			"storage_container_name": {
 				Type:     schema.TypeString,
 				Required: true,
@@ -69,6 +72,10 @@ func resourceArmStorageBlob() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"content_md5": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 			"parallelism": {
 				Type:         schema.TypeInt,
 				Optional:     true,
@@ -91,7 +98,7 @@ func validateArmStorageBlobParallelism(v interface{}, k string) (ws []string, er
 	value := v.(int)
 
 	if value <= 0 {
-		errors = append(errors, fmt.Errorf("Blob Parallelism %q is invalid, must be greater than 0", value))
+		errors = append(errors, fmt.Errorf("Blob Parallelism %d is invalid, must be greater than 0", value))
 	}
 
 	return
@@ -101,7 +108,7 @@ func validateArmStorageBlobAttempts(v interface{}, k string) (ws []string, error
 	value := v.(int)
 
 	if value <= 0 {
-		errors = append(errors, fmt.Errorf("Blob Attempts %q is invalid, must be greater than 0", value))
+		errors = append(errors, fmt.Errorf("Blob Attempts %d is invalid, must be greater than 0", value))
 	}
 
 	return
@@ -111,7 +118,7 @@ func validateArmStorageBlobSize(v interface{}, k string) (ws []string, errors []
 	value := v.(int)
 
 	if value%512 != 0 {
-		errors = append(errors, fmt.Errorf("Blob Size %q is invalid, must be a multiple of 512", value))
+		errors = append(errors, fmt.Errorf("Blob Size %d is invalid, must be a multiple of 512", value))
 	}
 
 	return
@@ -135,8 +142,9 @@ func resourceArmStorageBlobCreate(d *schema.ResourceData, meta interface{}) erro
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName)
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
@@ -173,9 +181,13 @@ func resourceArmStorageBlobCreate(d *schema.ResourceData, meta interface{}) erro
 			if source != "" {
 				parallelism := d.Get("parallelism").(int)
 				attempts := d.Get("attempts").(int)
-				if err := resourceArmStorageBlobBlockUploadFromSource(cont, name, source, blobClient, parallelism, attempts); err != nil {
+				contentMD5, err := resourceArmStorageBlobBlockUploadFromSource(cont, name, source, blobClient, parallelism, attempts)
+				if err != nil {
 					return fmt.Errorf("Error creating storage blob on Azure: %s", err)
 				}
+
+				// this is used to detect when the blob's been modified outside of Terraform
+				d.Set("content_md5", contentMD5)
 			}
 		case "page":
 			source := d.Get("source").(string)
@@ -386,18 +398,25 @@ type resourceArmStorageBlobBlock struct {
 	id      string
 }
 
-func resourceArmStorageBlobBlockUploadFromSource(container, name, source string, client *storage.BlobStorageClient, parallelism, attempts int) error {
+// resourceArmStorageBlobBlockUploadFromSource uploads the source file as a block blob, returning
+// the (base64 encoded) MD5 hash of the source file, which is set as the blob's Content-MD5
+func resourceArmStorageBlobBlockUploadFromSource(container, name, source string, client *storage.BlobStorageClient, parallelism, attempts int) (string, error) {
 	workerCount := parallelism * runtime.NumCPU()
 
 	file, err := os.Open(source)
 	if err != nil {
-		return fmt.Errorf("Error opening source file for upload %q: %s", source, err)
+		return "", fmt.Errorf("Error opening source file for upload %q: %s", source, err)
 	}
 	defer file.Close()
 
+	contentMD5, err := resourceArmStorageBlobComputeMD5(file)
+	if err != nil {
+		return "", fmt.Errorf("Error computing the MD5 hash of source file %q: %s", source, err)
+	}
+
 	blockList, parts, err := resourceArmStorageBlobBlockSplit(file)
 	if err != nil {
-		return fmt.Errorf("Error reading and splitting source file for upload %q: %s", source, err)
+		return "", fmt.Errorf("Error reading and splitting source file for upload %q: %s", source, err)
 	}
 
 	wg := &sync.WaitGroup{}
@@ -426,18 +445,42 @@ func resourceArmStorageBlobBlockUploadFromSource(container, name, source string,
 	wg.Wait()
 
 	if len(errors) > 0 {
-		return fmt.Errorf("Error while uploading source file %q: %s", source, <-errors)
+		return "", fmt.Errorf("Error while uploading source file %q: %s", source, <-errors)
 	}
 
 	containerReference := client.GetContainerReference(container)
 	blobReference := containerReference.GetBlobReference(name)
+
+	// the Content-MD5 of each block is verified as it's uploaded, however the blob's Content-MD5 is
+	// set from the source file when the block list is committed
+	blobReference.Properties.ContentMD5 = contentMD5
+
 	options := &storage.PutBlockListOptions{}
 	err = blobReference.PutBlockList(blockList, options)
 	if err != nil {
-		return fmt.Errorf("Error updating block list for source file %q: %s", source, err)
+		return "", fmt.Errorf("Error updating block list for source file %q: %s", source, err)
 	}
 
-	return nil
+	return contentMD5, nil
+}
+
+// resourceArmStorageBlobComputeMD5 returns the base64 encoded MD5 hash of the specified file,
+// which is the format used for the Content-MD5 header
+func resourceArmStorageBlobComputeMD5(file io.ReadSeeker) (string, error) {
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	hash := md5.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(hash.Sum(nil)), nil
 }
 
 func resourceArmStorageBlobBlockSplit(file *os.File) ([]storage.Block, []resourceArmStorageBlobBlock, error) {
@@ -504,10 +547,15 @@ func resourceArmStorageBlobBlockUploadWorker(ctx resourceArmStorageBlobBlockUplo
 			continue
 		}
 
+		// the Storage Service verifies each block against its hash, so corrupted blocks are rejected
+		blockMD5 := md5.Sum(buffer)
+
 		for i := 0; i < ctx.attempts; i++ {
 			container := ctx.client.GetContainerReference(ctx.container)
 			blob := container.GetBlobReference(ctx.name)
-			options := &storage.PutBlockOptions{}
+			options := &storage.PutBlockOptions{
+				ContentMD5: base64.StdEncoding.EncodeToString(blockMD5[:]),
+			}
 			err = blob.PutBlock(block.id, buffer, options)
 			if err == nil {
 				break
@@ -523,13 +571,19 @@ func resourceArmStorageBlobBlockUploadWorker(ctx resourceArmStorageBlobBlockUplo
 	}
 }
 
+// only the `storage_account_key` can be changed in-place, which is used when reading the Blob
+func resourceArmStorageBlobUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceArmStorageBlobRead(d, meta)
+}
+
 func resourceArmStorageBlobRead(d *schema.ResourceData, meta interface{}) error {
 	armClient := meta.(*ArmClient)
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName)
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
@@ -554,6 +608,22 @@ func resourceArmStorageBlobRead(d *schema.ResourceData, meta interface{}) error
 
 	container := blobClient.GetContainerReference(storageContainerName)
 	blob := container.GetBlobReference(name)
+
+	if err := blob.GetProperties(&storage.GetBlobPropertiesOptions{}); err != nil {
+		return fmt.Errorf("Error retrieving properties of storage blob %q: %s", name, err)
+	}
+
+	// if the blob's been modified outside of Terraform, remove it from the state so that it's
+	// uploaded from the source again on the next apply
+	existingMD5 := d.Get("content_md5").(string)
+	isBlockBlobFromSource := strings.EqualFold(d.Get("type").(string), "block") && d.Get("source").(string) != ""
+	if isBlockBlobFromSource && existingMD5 != "" && blob.Properties.ContentMD5 != existingMD5 {
+		log.Printf("[INFO] The Content-MD5 of storage blob %q (%q) doesn't match the source (%q) - removing from state so it's uploaded again", name, blob.Properties.ContentMD5, existingMD5)
+		d.SetId("")
+		return nil
+	}
+	d.Set("content_md5", blob.Properties.ContentMD5)
+
 	url := blob.GetURL()
 	if url == "" {
 		log.Printf("[INFO] URL for %q is empty", name)
@@ -568,8 +638,9 @@ func resourceArmStorageBlobExists(d *schema.ResourceData, meta interface{}) (boo
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName)
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return false, err
 	}
@@ -603,8 +674,9 @@ func resourceArmStorageBlobDelete(d *schema.ResourceData, meta interface{}) erro
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName)
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
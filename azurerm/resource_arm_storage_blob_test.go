@@ -143,6 +143,44 @@ func TestResourceAzureRMStorageBlobAttempts_validation(t *testing.T) {
 	}
 }
 
+func TestResourceAzureRMStorageBlobComputeMD5(t *testing.T) {
+	cases := []struct {
+		Value    string
+		Expected string
+	}{
+		{
+			Value:    "",
+			Expected: "1B2M2Y8AsgTpgAmY7PhCfg==",
+		},
+		{
+			Value:    "hello world",
+			Expected: "XrY7u+Ae7tCTyyK7j1rNww==",
+		},
+	}
+
+	for _, tc := range cases {
+		reader := strings.NewReader(tc.Value)
+
+		// the hash should be computed from the start of the file, regardless of the current position
+		if _, err := reader.Seek(2, io.SeekStart); err != nil && tc.Value != "" {
+			t.Fatalf("Error seeking: %s", err)
+		}
+
+		actual, err := resourceArmStorageBlobComputeMD5(reader)
+		if err != nil {
+			t.Fatalf("Expected no error computing the MD5 of %q but got: %s", tc.Value, err)
+		}
+
+		if actual != tc.Expected {
+			t.Fatalf("Expected the MD5 of %q to be %q but got %q", tc.Value, tc.Expected, actual)
+		}
+
+		if position, _ := reader.Seek(0, io.SeekCurrent); position != 0 {
+			t.Fatalf("Expected the reader to be reset to the start but it's at %d", position)
+		}
+	}
+}
+
 func TestAccAzureRMStorageBlob_basic(t *testing.T) {
 	ri := acctest.RandInt()
 	rs := strings.ToLower(acctest.RandString(11))
@@ -214,6 +252,7 @@ func TestAccAzureRMStorageBlobBlock_source(t *testing.T) {
 				Config: config,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckAzureRMStorageBlobMatchesFile("azurerm_storage_blob.source", storage.BlobTypeBlock, sourceBlob.Name()),
+					resource.TestCheckResourceAttrSet("azurerm_storage_blob.source", "content_md5"),
 				),
 			},
 		},
@@ -331,7 +370,7 @@ func testCheckAzureRMStorageBlobExists(name string) resource.TestCheckFunc {
 		}
 
 		armClient := testAccProvider.Meta().(*ArmClient)
-		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName)
+		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName, "")
 		if err != nil {
 			return err
 		}
@@ -371,7 +410,7 @@ func testCheckAzureRMStorageBlobDisappears(name string) resource.TestCheckFunc {
 		}
 
 		armClient := testAccProvider.Meta().(*ArmClient)
-		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName)
+		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName, "")
 		if err != nil {
 			return err
 		}
@@ -408,7 +447,7 @@ func testCheckAzureRMStorageBlobMatchesFile(name string, kind storage.BlobType,
 		}
 
 		armClient := testAccProvider.Meta().(*ArmClient)
-		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName)
+		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName, "")
 		if err != nil {
 			return err
 		}
@@ -470,7 +509,7 @@ func testCheckAzureRMStorageBlobDestroy(s *terraform.State) error {
 		}
 
 		armClient := testAccProvider.Meta().(*ArmClient)
-		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName)
+		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName, "")
 		if err != nil {
 			return nil
 		}
@@ -4,17 +4,23 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"regexp"
 
 	"github.com/Azure/azure-sdk-for-go/storage"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/helper/validation"
 )
 
+// the Storage Service supports a maximum of 5 Stored Access Policies per Container
+const storageContainerMaxAccessPolicies = 5
+
 func resourceArmStorageContainer() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmStorageContainerCreate,
 		Read:   resourceArmStorageContainerRead,
+		Update: resourceArmStorageContainerUpdate,
 		Exists: resourceArmStorageContainerExists,
 		Delete: resourceArmStorageContainerDelete,
 
@@ -31,13 +37,44 @@ func resourceArmStorageContainer() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"storage_account_key": storageAccountKeySchema(),
 			"container_access_type": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ForceNew:     true,
 				Default:      "private",
 				ValidateFunc: validateArmStorageContainerAccessType,
 			},
+			"access_policy": {
+				Type:     schema.TypeList,
+				Optional: true,
+				MaxItems: storageContainerMaxAccessPolicies,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringLenBetween(1, 64),
+						},
+						"start": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validateRFC3339Date,
+							DiffSuppressFunc: storageContainerAccessPolicyTimeDiffSuppressFunc,
+						},
+						"expiry": {
+							Type:             schema.TypeString,
+							Required:         true,
+							ValidateFunc:     validateRFC3339Date,
+							DiffSuppressFunc: storageContainerAccessPolicyTimeDiffSuppressFunc,
+						},
+						"permissions": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validateArmStorageContainerAccessPolicyPermissions,
+						},
+					},
+				},
+			},
 			"properties": {
 				Type:     schema.TypeMap,
 				Computed: true,
@@ -74,18 +111,77 @@ func validateArmStorageContainerAccessType(v interface{}, k string) (ws []string
 	}
 
 	if _, ok := validTypes[value]; !ok {
-		errors = append(errors, fmt.Errorf("Storage container access type %q is invalid, must be %q, %q or %q", value, "private", "blob", "page"))
+		errors = append(errors, fmt.Errorf("Storage container access type %q is invalid, must be %q, %q or %q", value, "private", "blob", "container"))
 	}
 	return
 }
 
+// the Storage Service only supports the read, write and delete permissions (in that order)
+// within a Stored Access Policy for a Container
+func validateArmStorageContainerAccessPolicyPermissions(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if !regexp.MustCompile(`^(r?w?d?)$`).MatchString(value) || value == "" {
+		errors = append(errors, fmt.Errorf(
+			"%q must be a combination of %q, %q and %q (in that order): %q", k, "r", "w", "d", value))
+	}
+	return
+}
+
+// validateStorageContainerAccessPolicies ensures that the ID of each Stored Access Policy is
+// unique and that the expiry of each policy is after it's start
+func validateStorageContainerAccessPolicies(input []interface{}) error {
+	ids := make(map[string]struct{}, len(input))
+
+	for _, v := range input {
+		policy := v.(map[string]interface{})
+		id := policy["id"].(string)
+
+		if _, exists := ids[id]; exists {
+			return fmt.Errorf("The `id` of each `access_policy` must be unique - %q is specified more than once", id)
+		}
+		ids[id] = struct{}{}
+
+		start, err := time.Parse(time.RFC3339, policy["start"].(string))
+		if err != nil {
+			return fmt.Errorf("Error parsing `start` for the `access_policy` %q: %+v", id, err)
+		}
+
+		expiry, err := time.Parse(time.RFC3339, policy["expiry"].(string))
+		if err != nil {
+			return fmt.Errorf("Error parsing `expiry` for the `access_policy` %q: %+v", id, err)
+		}
+
+		if !expiry.After(start) {
+			return fmt.Errorf("The `expiry` of the `access_policy` %q must be after the `start`", id)
+		}
+	}
+
+	return nil
+}
+
+// the Storage Service returns times in UTC, so we need to compare the times rather than the strings
+func storageContainerAccessPolicyTimeDiffSuppressFunc(k, old, new string, d *schema.ResourceData) bool {
+	oldTime, err := time.Parse(time.RFC3339, old)
+	if err != nil {
+		return false
+	}
+
+	newTime, err := time.Parse(time.RFC3339, new)
+	if err != nil {
+		return false
+	}
+
+	return oldTime.Equal(newTime)
+}
+
 func resourceArmStorageContainerCreate(d *schema.ResourceData, meta interface{}) error {
 	armClient := meta.(*ArmClient)
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName)
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
@@ -95,11 +191,10 @@ func resourceArmStorageContainerCreate(d *schema.ResourceData, meta interface{})
 
 	name := d.Get("name").(string)
 
-	var accessType storage.ContainerAccessType
-	if d.Get("container_access_type").(string) == "private" {
-		accessType = storage.ContainerAccessType("")
-	} else {
-		accessType = storage.ContainerAccessType(d.Get("container_access_type").(string))
+	accessType := d.Get("container_access_type").(string)
+	accessPolicies := d.Get("access_policy").([]interface{})
+	if err := validateStorageContainerAccessPolicies(accessPolicies); err != nil {
+		return err
 	}
 
 	log.Printf("[INFO] Creating container %q in storage account %q.", name, storageAccountName)
@@ -111,9 +206,7 @@ func resourceArmStorageContainerCreate(d *schema.ResourceData, meta interface{})
 		return fmt.Errorf("Error creating container %q in storage account %q: %s", name, storageAccountName, err)
 	}
 
-	permissions := storage.ContainerPermissions{
-		AccessType: accessType,
-	}
+	permissions := expandStorageContainerPermissions(accessType, accessPolicies)
 	permissionOptions := &storage.SetContainerPermissionOptions{}
 	err = reference.SetPermissions(permissions, permissionOptions)
 	if err != nil {
@@ -124,6 +217,45 @@ func resourceArmStorageContainerCreate(d *schema.ResourceData, meta interface{})
 	return resourceArmStorageContainerRead(d, meta)
 }
 
+func resourceArmStorageContainerUpdate(d *schema.ResourceData, meta interface{}) error {
+	armClient := meta.(*ArmClient)
+
+	resourceGroupName := d.Get("resource_group_name").(string)
+	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
+
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
+	if err != nil {
+		return err
+	}
+	if !accountExists {
+		return fmt.Errorf("Storage Account %q Not Found", storageAccountName)
+	}
+
+	name := d.Get("name").(string)
+
+	if d.HasChange("container_access_type") || d.HasChange("access_policy") {
+		accessType := d.Get("container_access_type").(string)
+		accessPolicies := d.Get("access_policy").([]interface{})
+		if err := validateStorageContainerAccessPolicies(accessPolicies); err != nil {
+			return err
+		}
+
+		log.Printf("[INFO] Updating the Access Type and Access Policies for container %q in storage account %q.", name, storageAccountName)
+		reference := blobClient.GetContainerReference(name)
+
+		// the Access Type and Access Policies are set in a single call, so both are always sent
+		permissions := expandStorageContainerPermissions(accessType, accessPolicies)
+		permissionOptions := &storage.SetContainerPermissionOptions{}
+		err = reference.SetPermissions(permissions, permissionOptions)
+		if err != nil {
+			return fmt.Errorf("Error setting permissions for container %s in storage account %s: %+v", name, storageAccountName, err)
+		}
+	}
+
+	return resourceArmStorageContainerRead(d, meta)
+}
+
 // resourceAzureStorageContainerRead does all the necessary API calls to
 // read the status of the storage container off Azure.
 func resourceArmStorageContainerRead(d *schema.ResourceData, meta interface{}) error {
@@ -131,8 +263,9 @@ func resourceArmStorageContainerRead(d *schema.ResourceData, meta interface{}) e
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName)
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
@@ -169,6 +302,19 @@ func resourceArmStorageContainerRead(d *schema.ResourceData, meta interface{}) e
 	if !found {
 		log.Printf("[INFO] Storage container %q does not exist in account %q, removing from state...", name, storageAccountName)
 		d.SetId("")
+		return nil
+	}
+
+	reference := blobClient.GetContainerReference(name)
+	permissions, err := reference.GetPermissions(&storage.GetContainerPermissionOptions{})
+	if err != nil {
+		return fmt.Errorf("Error retrieving permissions for container %q in storage account %q: %s", name, storageAccountName, err)
+	}
+
+	d.Set("container_access_type", flattenStorageContainerAccessType(permissions.AccessType))
+
+	if err := d.Set("access_policy", flattenStorageContainerAccessPolicies(permissions.AccessPolicies)); err != nil {
+		return fmt.Errorf("Error flattening `access_policy`: %+v", err)
 	}
 
 	return nil
@@ -179,8 +325,9 @@ func resourceArmStorageContainerExists(d *schema.ResourceData, meta interface{})
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName)
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return false, err
 	}
@@ -214,8 +361,9 @@ func resourceArmStorageContainerDelete(d *schema.ResourceData, meta interface{})
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName)
+	blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
@@ -236,3 +384,70 @@ func resourceArmStorageContainerDelete(d *schema.ResourceData, meta interface{})
 	d.SetId("")
 	return nil
 }
+
+func expandStorageContainerPermissions(accessType string, input []interface{}) storage.ContainerPermissions {
+	permissions := storage.ContainerPermissions{
+		AccessType:     storage.ContainerAccessType(""),
+		AccessPolicies: make([]storage.ContainerAccessPolicy, 0, len(input)),
+	}
+
+	if accessType != "private" {
+		permissions.AccessType = storage.ContainerAccessType(accessType)
+	}
+
+	for _, v := range input {
+		policy := v.(map[string]interface{})
+		policyPermissions := policy["permissions"].(string)
+
+		// these have already been validated
+		start, _ := time.Parse(time.RFC3339, policy["start"].(string))
+		expiry, _ := time.Parse(time.RFC3339, policy["expiry"].(string))
+
+		permissions.AccessPolicies = append(permissions.AccessPolicies, storage.ContainerAccessPolicy{
+			ID:         policy["id"].(string),
+			StartTime:  start.UTC(),
+			ExpiryTime: expiry.UTC(),
+			CanRead:    strings.Contains(policyPermissions, "r"),
+			CanWrite:   strings.Contains(policyPermissions, "w"),
+			CanDelete:  strings.Contains(policyPermissions, "d"),
+		})
+	}
+
+	return permissions
+}
+
+// the Storage Service returns an empty Access Type for private containers
+func flattenStorageContainerAccessType(input storage.ContainerAccessType) string {
+	if input == storage.ContainerAccessTypePrivate {
+		return "private"
+	}
+
+	return string(input)
+}
+
+func flattenStorageContainerAccessPolicies(input []storage.ContainerAccessPolicy) []interface{} {
+	policies := make([]interface{}, 0, len(input))
+
+	for _, v := range input {
+		permissions := ""
+		if v.CanRead {
+			permissions += "r"
+		}
+		if v.CanWrite {
+			permissions += "w"
+		}
+		if v.CanDelete {
+			permissions += "d"
+		}
+
+		policy := map[string]interface{}{
+			"id":          v.ID,
+			"start":       v.StartTime.UTC().Format(time.RFC3339),
+			"expiry":      v.ExpiryTime.UTC().Format(time.RFC3339),
+			"permissions": permissions,
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies
+}
@@ -3,6 +3,7 @@ package azurerm
 import (
 	"fmt"
 	"log"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -34,6 +35,96 @@ func TestAccAzureRMStorageContainer_basic(t *testing.T) {
 	})
 }
 
+func TestAccAzureRMStorageContainer_accessPolicy(t *testing.T) {
+	var c storage.Container
+
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	location := testLocation()
+	config := testAccAzureRMStorageContainer_accessPolicy(ri, rs, location)
+	updatedConfig := testAccAzureRMStorageContainer_accessPolicyUpdated(ri, rs, location)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageContainerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageContainerExists("azurerm_storage_container.test", &c),
+					resource.TestCheckResourceAttr("azurerm_storage_container.test", "access_policy.#", "1"),
+					resource.TestCheckResourceAttr("azurerm_storage_container.test", "access_policy.0.id", "read-only"),
+					resource.TestCheckResourceAttr("azurerm_storage_container.test", "access_policy.0.permissions", "r"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageContainerExists("azurerm_storage_container.test", &c),
+					resource.TestCheckResourceAttr("azurerm_storage_container.test", "access_policy.#", "2"),
+					resource.TestCheckResourceAttr("azurerm_storage_container.test", "access_policy.1.id", "read-write"),
+					resource.TestCheckResourceAttr("azurerm_storage_container.test", "access_policy.1.permissions", "rw"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageContainer_update(t *testing.T) {
+	var c storage.Container
+
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	location := testLocation()
+	config := testAccAzureRMStorageContainer_basic(ri, rs, location)
+	updatedConfig := testAccAzureRMStorageContainer_accessTypeUpdated(ri, rs, location)
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageContainerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageContainerExists("azurerm_storage_container.test", &c),
+					resource.TestCheckResourceAttr("azurerm_storage_container.test", "container_access_type", "private"),
+				),
+			},
+			{
+				Config: updatedConfig,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageContainerExists("azurerm_storage_container.test", &c),
+					resource.TestCheckResourceAttr("azurerm_storage_container.test", "container_access_type", "container"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAzureRMStorageContainer_storageAccountKey(t *testing.T) {
+	var c storage.Container
+
+	ri := acctest.RandInt()
+	rs := strings.ToLower(acctest.RandString(11))
+	config := testAccAzureRMStorageContainer_storageAccountKey(ri, rs, testLocation())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckAzureRMStorageContainerDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckAzureRMStorageContainerExists("azurerm_storage_container.test", &c),
+				),
+			},
+		},
+	})
+}
+
 func TestAccAzureRMStorageContainer_disappears(t *testing.T) {
 	var c storage.Container
 
@@ -97,7 +188,7 @@ func testCheckAzureRMStorageContainerExists(name string, c *storage.Container) r
 		}
 
 		armClient := testAccProvider.Meta().(*ArmClient)
-		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName)
+		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName, "")
 		if err != nil {
 			return err
 		}
@@ -145,7 +236,7 @@ func testAccARMStorageContainerDisappears(name string, c *storage.Container) res
 			return fmt.Errorf("Bad: no resource group found in state for storage container: %s", c.Name)
 		}
 
-		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName)
+		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName, "")
 		if err != nil {
 			return err
 		}
@@ -179,7 +270,7 @@ func testCheckAzureRMStorageContainerDestroy(s *terraform.State) error {
 		}
 
 		armClient := testAccProvider.Meta().(*ArmClient)
-		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName)
+		blobClient, accountExists, err := armClient.getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName, "")
 		if err != nil {
 			//If we can't get keys then the blob can't exist
 			return nil
@@ -212,6 +303,157 @@ func testCheckAzureRMStorageContainerDestroy(s *terraform.State) error {
 	return nil
 }
 
+func TestValidateArmStorageContainerAccessPolicyPermissions(t *testing.T) {
+	testCases := []struct {
+		input string
+		valid bool
+	}{
+		{"r", true},
+		{"w", true},
+		{"d", true},
+		{"rw", true},
+		{"rwd", true},
+		{"rd", true},
+		{"", false},
+		{"wr", false},
+		{"rwdl", false},
+		{"R", false},
+	}
+
+	for _, test := range testCases {
+		_, errors := validateArmStorageContainerAccessPolicyPermissions(test.input, "permissions")
+		if valid := len(errors) == 0; valid != test.valid {
+			t.Fatalf("Expected %q to be valid %t but got %t", test.input, test.valid, valid)
+		}
+	}
+}
+
+func TestValidateStorageContainerAccessPolicies(t *testing.T) {
+	policy := func(id, start, expiry string) map[string]interface{} {
+		return map[string]interface{}{
+			"id":          id,
+			"start":       start,
+			"expiry":      expiry,
+			"permissions": "r",
+		}
+	}
+
+	testCases := []struct {
+		input []interface{}
+		valid bool
+	}{
+		{
+			input: []interface{}{},
+			valid: true,
+		},
+		{
+			input: []interface{}{
+				policy("first", "2018-03-21T00:00:00Z", "2018-03-22T00:00:00Z"),
+				policy("second", "2018-03-21T00:00:00Z", "2018-03-22T00:00:00+01:00"),
+			},
+			valid: true,
+		},
+		{
+			input: []interface{}{
+				policy("first", "2018-03-21T00:00:00Z", "2018-03-22T00:00:00Z"),
+				policy("first", "2018-03-21T00:00:00Z", "2018-03-23T00:00:00Z"),
+			},
+			valid: false,
+		},
+		{
+			input: []interface{}{
+				policy("first", "2018-03-22T00:00:00Z", "2018-03-21T00:00:00Z"),
+			},
+			valid: false,
+		},
+		{
+			input: []interface{}{
+				policy("first", "2018-03-22T00:00:00Z", "2018-03-22T00:00:00Z"),
+			},
+			valid: false,
+		},
+	}
+
+	for _, test := range testCases {
+		err := validateStorageContainerAccessPolicies(test.input)
+		if valid := err == nil; valid != test.valid {
+			t.Fatalf("Expected %+v to be valid %t but got %t: %+v", test.input, test.valid, valid, err)
+		}
+	}
+}
+
+func TestStorageContainerAccessPolicies_roundTrip(t *testing.T) {
+	input := []interface{}{
+		map[string]interface{}{
+			"id":          "read-only",
+			"start":       "2018-03-21T01:00:00+01:00",
+			"expiry":      "2018-03-22T00:00:00Z",
+			"permissions": "r",
+		},
+		map[string]interface{}{
+			"id":          "all",
+			"start":       "2018-03-21T00:00:00Z",
+			"expiry":      "2018-03-22T00:00:00Z",
+			"permissions": "rwd",
+		},
+	}
+	expected := []interface{}{
+		map[string]interface{}{
+			"id":          "read-only",
+			"start":       "2018-03-21T00:00:00Z",
+			"expiry":      "2018-03-22T00:00:00Z",
+			"permissions": "r",
+		},
+		map[string]interface{}{
+			"id":          "all",
+			"start":       "2018-03-21T00:00:00Z",
+			"expiry":      "2018-03-22T00:00:00Z",
+			"permissions": "rwd",
+		},
+	}
+
+	permissions := expandStorageContainerPermissions("blob", input)
+	if permissions.AccessType != storage.ContainerAccessTypeBlob {
+		t.Fatalf("Expected the Access Type to be %q but got %q", storage.ContainerAccessTypeBlob, permissions.AccessType)
+	}
+
+	actual := flattenStorageContainerAccessPolicies(permissions.AccessPolicies)
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Expected %+v but got %+v", expected, actual)
+	}
+
+	if private := expandStorageContainerPermissions("private", input); private.AccessType != storage.ContainerAccessTypePrivate {
+		t.Fatalf("Expected the Access Type to be private but got %q", private.AccessType)
+	}
+}
+
+func TestFlattenStorageContainerAccessType(t *testing.T) {
+	testCases := []struct {
+		input    storage.ContainerAccessType
+		expected string
+	}{
+		{
+			input:    storage.ContainerAccessTypePrivate,
+			expected: "private",
+		},
+		{
+			input:    storage.ContainerAccessTypeBlob,
+			expected: "blob",
+		},
+		{
+			input:    storage.ContainerAccessTypeContainer,
+			expected: "container",
+		},
+	}
+
+	for _, test := range testCases {
+		actual := flattenStorageContainerAccessType(test.input)
+		if actual != test.expected {
+			t.Fatalf("Expected %q to flatten to %q but got %q", test.input, test.expected, actual)
+		}
+	}
+}
+
 func TestValidateArmStorageContainerName(t *testing.T) {
 	validNames := []string{
 		"valid-name",
@@ -270,6 +512,136 @@ resource "azurerm_storage_container" "test" {
 `, rInt, location, rString)
 }
 
+func testAccAzureRMStorageContainer_accessTypeUpdated(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+    name = "acctestRG-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+    name                     = "acctestacc%s"
+    resource_group_name      = "${azurerm_resource_group.test.name}"
+    location                 = "${azurerm_resource_group.test.location}"
+    account_tier             = "Standard"
+    account_replication_type = "LRS"
+
+    tags {
+        environment = "staging"
+    }
+}
+
+resource "azurerm_storage_container" "test" {
+    name = "vhds"
+    resource_group_name = "${azurerm_resource_group.test.name}"
+    storage_account_name = "${azurerm_storage_account.test.name}"
+    container_access_type = "container"
+}
+`, rInt, location, rString)
+}
+
+func testAccAzureRMStorageContainer_storageAccountKey(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+    name = "acctestRG-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+    name                     = "acctestacc%s"
+    resource_group_name      = "${azurerm_resource_group.test.name}"
+    location                 = "${azurerm_resource_group.test.location}"
+    account_tier             = "Standard"
+    account_replication_type = "LRS"
+}
+
+resource "azurerm_storage_container" "test" {
+    name = "vhds"
+    resource_group_name = "${azurerm_resource_group.test.name}"
+    storage_account_name = "${azurerm_storage_account.test.name}"
+    storage_account_key = "${azurerm_storage_account.test.secondary_access_key}"
+    container_access_type = "private"
+}
+`, rInt, location, rString)
+}
+
+func testAccAzureRMStorageContainer_accessPolicy(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+    name = "acctestRG-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+    name                     = "acctestacc%s"
+    resource_group_name      = "${azurerm_resource_group.test.name}"
+    location                 = "${azurerm_resource_group.test.location}"
+    account_tier             = "Standard"
+    account_replication_type = "LRS"
+
+    tags {
+        environment = "staging"
+    }
+}
+
+resource "azurerm_storage_container" "test" {
+    name = "vhds"
+    resource_group_name = "${azurerm_resource_group.test.name}"
+    storage_account_name = "${azurerm_storage_account.test.name}"
+    container_access_type = "private"
+
+    access_policy {
+        id          = "read-only"
+        start       = "2018-03-21T00:00:00Z"
+        expiry      = "2028-03-21T00:00:00Z"
+        permissions = "r"
+    }
+}
+`, rInt, location, rString)
+}
+
+func testAccAzureRMStorageContainer_accessPolicyUpdated(rInt int, rString string, location string) string {
+	return fmt.Sprintf(`
+resource "azurerm_resource_group" "test" {
+    name = "acctestRG-%d"
+    location = "%s"
+}
+
+resource "azurerm_storage_account" "test" {
+    name                     = "acctestacc%s"
+    resource_group_name      = "${azurerm_resource_group.test.name}"
+    location                 = "${azurerm_resource_group.test.location}"
+    account_tier             = "Standard"
+    account_replication_type = "LRS"
+
+    tags {
+        environment = "staging"
+    }
+}
+
+resource "azurerm_storage_container" "test" {
+    name = "vhds"
+    resource_group_name = "${azurerm_resource_group.test.name}"
+    storage_account_name = "${azurerm_storage_account.test.name}"
+    container_access_type = "private"
+
+    access_policy {
+        id          = "read-only"
+        start       = "2018-03-21T00:00:00Z"
+        expiry      = "2028-03-21T00:00:00Z"
+        permissions = "r"
+    }
+
+    access_policy {
+        id          = "read-write"
+        start       = "2018-03-21T00:00:00Z"
+        expiry      = "2028-03-21T00:00:00Z"
+        permissions = "rw"
+    }
+}
+`, rInt, location, rString)
+}
+
 func testAccAzureRMStorageContainer_root(rInt int, rString string, location string) string {
 	return fmt.Sprintf(`
 resource "azurerm_resource_group" "test" {
@@ -13,6 +13,7 @@ func resourceArmStorageQueue() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmStorageQueueCreate,
 		Read:   resourceArmStorageQueueRead,
+		Update: resourceArmStorageQueueUpdate,
 		Exists: resourceArmStorageQueueExists,
 		Delete: resourceArmStorageQueueDelete,
 
@@ -29,6 +30,7 @@ func resourceArmStorageQueue() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"storage_account_key": storageAccountKeySchema(),
 		},
 	}
 }
@@ -67,8 +69,9 @@ func resourceArmStorageQueueCreate(d *schema.ResourceData, meta interface{}) err
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	queueClient, accountExists, err := armClient.getQueueServiceClientForStorageAccount(resourceGroupName, storageAccountName)
+	queueClient, accountExists, err := armClient.getQueueServiceClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
@@ -90,6 +93,11 @@ func resourceArmStorageQueueCreate(d *schema.ResourceData, meta interface{}) err
 	return resourceArmStorageQueueRead(d, meta)
 }
 
+// only the `storage_account_key` can be changed in-place, which is used when reading the Queue
+func resourceArmStorageQueueUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceArmStorageQueueRead(d, meta)
+}
+
 func resourceArmStorageQueueRead(d *schema.ResourceData, meta interface{}) error {
 
 	exists, err := resourceArmStorageQueueExists(d, meta)
@@ -110,8 +118,9 @@ func resourceArmStorageQueueExists(d *schema.ResourceData, meta interface{}) (bo
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	queueClient, accountExists, err := armClient.getQueueServiceClientForStorageAccount(resourceGroupName, storageAccountName)
+	queueClient, accountExists, err := armClient.getQueueServiceClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return false, err
 	}
@@ -143,8 +152,9 @@ func resourceArmStorageQueueDelete(d *schema.ResourceData, meta interface{}) err
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	queueClient, accountExists, err := armClient.getQueueServiceClientForStorageAccount(resourceGroupName, storageAccountName)
+	queueClient, accountExists, err := armClient.getQueueServiceClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
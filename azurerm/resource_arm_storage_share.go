@@ -13,6 +13,7 @@ func resourceArmStorageShare() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmStorageShareCreate,
 		Read:   resourceArmStorageShareRead,
+		Update: resourceArmStorageShareUpdate,
 		Exists: resourceArmStorageShareExists,
 		Delete: resourceArmStorageShareDelete,
 
@@ -29,6 +30,7 @@ func resourceArmStorageShare() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"storage_account_key": storageAccountKeySchema(),
 			"quota": {
 				Type:     schema.TypeInt,
 				Optional: true,
@@ -47,8 +49,9 @@ func resourceArmStorageShareCreate(d *schema.ResourceData, meta interface{}) err
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName)
+	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
@@ -78,13 +81,19 @@ func resourceArmStorageShareCreate(d *schema.ResourceData, meta interface{}) err
 	return resourceArmStorageShareRead(d, meta)
 }
 
+// only the `storage_account_key` can be changed in-place, which is used when reading the Share
+func resourceArmStorageShareUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceArmStorageShareRead(d, meta)
+}
+
 func resourceArmStorageShareRead(d *schema.ResourceData, meta interface{}) error {
 	armClient := meta.(*ArmClient)
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName)
+	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
@@ -121,8 +130,9 @@ func resourceArmStorageShareExists(d *schema.ResourceData, meta interface{}) (bo
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName)
+	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return false, err
 	}
@@ -154,8 +164,9 @@ func resourceArmStorageShareDelete(d *schema.ResourceData, meta interface{}) err
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName)
+	fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
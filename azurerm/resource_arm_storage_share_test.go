@@ -74,7 +74,7 @@ func testCheckAzureRMStorageShareExists(name string, sS *storage.Share) resource
 		}
 
 		armClient := testAccProvider.Meta().(*ArmClient)
-		fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName)
+		fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName, "")
 		if err != nil {
 			return err
 		}
@@ -122,7 +122,7 @@ func testAccARMStorageShareDisappears(name string, sS *storage.Share) resource.T
 			return fmt.Errorf("Bad: no resource group found in state for storage share: %s", sS.Name)
 		}
 
-		fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName)
+		fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName, "")
 		if err != nil {
 			return err
 		}
@@ -157,7 +157,7 @@ func testCheckAzureRMStorageShareDestroy(s *terraform.State) error {
 		}
 
 		armClient := testAccProvider.Meta().(*ArmClient)
-		fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName)
+		fileClient, accountExists, err := armClient.getFileServiceClientForStorageAccount(resourceGroupName, storageAccountName, "")
 		if err != nil {
 			//If we can't get keys then the blob can't exist
 			return nil
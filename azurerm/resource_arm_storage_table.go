@@ -13,6 +13,7 @@ func resourceArmStorageTable() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceArmStorageTableCreate,
 		Read:   resourceArmStorageTableRead,
+		Update: resourceArmStorageTableUpdate,
 		Delete: resourceArmStorageTableDelete,
 
 		Schema: map[string]*schema.Schema{
@@ -28,6 +29,7 @@ func resourceArmStorageTable() *schema.Resource {
 				Required: true,
 				ForceNew: true,
 			},
+			"storage_account_key": storageAccountKeySchema(),
 		},
 	}
 }
@@ -53,8 +55,9 @@ func resourceArmStorageTableCreate(d *schema.ResourceData, meta interface{}) err
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(resourceGroupName, storageAccountName)
+	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
@@ -79,13 +82,19 @@ func resourceArmStorageTableCreate(d *schema.ResourceData, meta interface{}) err
 	return resourceArmStorageTableRead(d, meta)
 }
 
+// only the `storage_account_key` can be changed in-place, which is used when reading the Table
+func resourceArmStorageTableUpdate(d *schema.ResourceData, meta interface{}) error {
+	return resourceArmStorageTableRead(d, meta)
+}
+
 func resourceArmStorageTableRead(d *schema.ResourceData, meta interface{}) error {
 	armClient := meta.(*ArmClient)
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(resourceGroupName, storageAccountName)
+	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
@@ -125,8 +134,9 @@ func resourceArmStorageTableDelete(d *schema.ResourceData, meta interface{}) err
 
 	resourceGroupName := d.Get("resource_group_name").(string)
 	storageAccountName := d.Get("storage_account_name").(string)
+	storageAccountKey := d.Get("storage_account_key").(string)
 
-	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(resourceGroupName, storageAccountName)
+	tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(resourceGroupName, storageAccountName, storageAccountKey)
 	if err != nil {
 		return err
 	}
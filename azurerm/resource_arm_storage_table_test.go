@@ -74,7 +74,7 @@ func testCheckAzureRMStorageTableExists(name string, t *storage.Table) resource.
 		}
 
 		armClient := testAccProvider.Meta().(*ArmClient)
-		tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(resourceGroup, storageAccountName)
+		tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(resourceGroup, storageAccountName, "")
 		if err != nil {
 			return err
 		}
@@ -120,7 +120,7 @@ func testAccARMStorageTableDisappears(name string, t *storage.Table) resource.Te
 			return fmt.Errorf("Bad: no resource group found in state for storage table: %s", t.Name)
 		}
 
-		tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(resourceGroup, storageAccountName)
+		tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(resourceGroup, storageAccountName, "")
 		if err != nil {
 			return err
 		}
@@ -155,7 +155,7 @@ func testCheckAzureRMStorageTableDestroy(s *terraform.State) error {
 		}
 
 		armClient := testAccProvider.Meta().(*ArmClient)
-		tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(resourceGroup, storageAccountName)
+		tableClient, accountExists, err := armClient.getTableServiceClientForStorageAccount(resourceGroup, storageAccountName, "")
 		if err != nil {
 			//If we can't get keys then the table can't exist
 			return nil
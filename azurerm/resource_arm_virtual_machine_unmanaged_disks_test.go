@@ -2847,7 +2847,7 @@ func testCheckAzureRMVirtualMachineVHDExistence(name string, shouldExist bool) r
 			resourceGroup := rs.Primary.Attributes["resource_group_name"]
 			storageAccountName := rs.Primary.Attributes["storage_account_name"]
 			containerName := rs.Primary.Attributes["name"]
-			storageClient, _, err := testAccProvider.Meta().(*ArmClient).getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName)
+			storageClient, _, err := testAccProvider.Meta().(*ArmClient).getBlobStorageClientForStorageAccount(resourceGroup, storageAccountName, "")
 			if err != nil {
 				return fmt.Errorf("Error creating Blob storage client: %+v", err)
 			}
@@ -135,6 +135,23 @@ func composeAzureResourceID(idObj *ResourceID) (id string, err error) {
 	return
 }
 
+// getAzureResourceIDPathValue returns the value of the specified key within the Path of the ResourceID.
+// The key is matched case-insensitively, since IDs copied from the Portal don't always use the same
+// casing for the resource type as the API does.
+func getAzureResourceIDPathValue(id *ResourceID, key string) (string, error) {
+	if value, ok := id.Path[key]; ok {
+		return value, nil
+	}
+
+	for k, v := range id.Path {
+		if strings.EqualFold(k, key) {
+			return v, nil
+		}
+	}
+
+	return "", fmt.Errorf("Error: %q was not found in the Resource ID", key)
+}
+
 func parseNetworkSecurityGroupName(networkSecurityGroupId string) (string, error) {
 	id, err := parseAzureResourceID(networkSecurityGroupId)
 	if err != nil {
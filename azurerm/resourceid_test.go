@@ -233,3 +233,60 @@ func TestComposeAzureResourceID(t *testing.T) {
 		}
 	}
 }
+
+func TestGetAzureResourceIDPathValue(t *testing.T) {
+	testCases := []struct {
+		id            string
+		key           string
+		expectedValue string
+		expectError   bool
+	}{
+		{
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Compute/snapshots/snapshot1",
+			"snapshots",
+			"snapshot1",
+			false,
+		},
+		{
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Compute/Snapshots/snapshot1",
+			"snapshots",
+			"snapshot1",
+			false,
+		},
+		{
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Compute/SNAPSHOTS/snapshot1",
+			"snapshots",
+			"snapshot1",
+			false,
+		},
+		{
+			"/subscriptions/00000000-0000-0000-0000-000000000000/resourceGroups/testGroup1/providers/Microsoft.Compute/disks/disk1",
+			"snapshots",
+			"",
+			true,
+		},
+	}
+
+	for _, test := range testCases {
+		id, err := parseAzureResourceID(test.id)
+		if err != nil {
+			t.Fatalf("Unexpected error parsing %q: %s", test.id, err)
+		}
+
+		value, err := getAzureResourceIDPathValue(id, test.key)
+		if test.expectError {
+			if err == nil {
+				t.Fatalf("Expected an error looking up %q in %q but didn't get one", test.key, test.id)
+			}
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		if value != test.expectedValue {
+			t.Fatalf("Expected %q but got %q", test.expectedValue, value)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package azurerm
+
+import (
+	"encoding/base64"
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// storageAccountKeySchema allows an Access Key to be specified for the Data Plane resources, for use by
+// principals which don't have permission to retrieve the keys for the Storage Account
+func storageAccountKeySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Sensitive:    true,
+		ValidateFunc: validateStorageAccountKey,
+	}
+}
+
+func validateStorageAccountKey(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a base64 encoded Storage Account Access Key: %+v", k, err))
+	}
+	return
+}
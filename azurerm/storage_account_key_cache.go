@@ -0,0 +1,50 @@
+package azurerm
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// storageAccountKeyCache caches the Access Key for each Storage Account, so that the Data Plane
+// resources (Containers, Blobs, Queues, Shares & Tables) only need to call ListKeys once per account
+// rather than once per operation. Since the cache is scoped to the Provider process, keys which are
+// regenerated outside of Terraform are picked up on the next run - whereas those regenerated by
+// Terraform (or accounts which are deleted/recreated) must be invalidated explicitly.
+type storageAccountKeyCache struct {
+	lock sync.RWMutex
+	keys map[string]string
+}
+
+func newStorageAccountKeyCache() *storageAccountKeyCache {
+	return &storageAccountKeyCache{
+		keys: make(map[string]string),
+	}
+}
+
+// Resource Group names are case-insensitive, and Storage Account names are always lower-case
+func storageAccountKeyCacheKey(resourceGroupName, storageAccountName string) string {
+	return strings.ToLower(fmt.Sprintf("%s/%s", resourceGroupName, storageAccountName))
+}
+
+func (c *storageAccountKeyCache) get(resourceGroupName, storageAccountName string) (string, bool) {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	key, ok := c.keys[storageAccountKeyCacheKey(resourceGroupName, storageAccountName)]
+	return key, ok
+}
+
+func (c *storageAccountKeyCache) set(resourceGroupName, storageAccountName, key string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.keys[storageAccountKeyCacheKey(resourceGroupName, storageAccountName)] = key
+}
+
+func (c *storageAccountKeyCache) invalidate(resourceGroupName, storageAccountName string) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.keys, storageAccountKeyCacheKey(resourceGroupName, storageAccountName))
+}
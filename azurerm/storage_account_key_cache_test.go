@@ -0,0 +1,36 @@
+package azurerm
+
+import "testing"
+
+func TestStorageAccountKeyCache(t *testing.T) {
+	cache := newStorageAccountKeyCache()
+
+	if _, ok := cache.get("example-resources", "examplestorage"); ok {
+		t.Fatalf("Expected an empty cache not to contain a key")
+	}
+
+	cache.set("example-resources", "examplestorage", "key1")
+
+	// Resource Group names are case-insensitive
+	key, ok := cache.get("Example-Resources", "examplestorage")
+	if !ok {
+		t.Fatalf("Expected the cache to contain a key for the Storage Account")
+	}
+	if key != "key1" {
+		t.Fatalf("Expected the key to be %q but got %q", "key1", key)
+	}
+
+	if _, ok := cache.get("other-resources", "examplestorage"); ok {
+		t.Fatalf("Expected the key to be scoped to the Resource Group")
+	}
+
+	cache.set("example-resources", "examplestorage", "key2")
+	if key, _ := cache.get("example-resources", "examplestorage"); key != "key2" {
+		t.Fatalf("Expected the key to be %q but got %q", "key2", key)
+	}
+
+	cache.invalidate("example-resources", "examplestorage")
+	if _, ok := cache.get("example-resources", "examplestorage"); ok {
+		t.Fatalf("Expected the key to be removed from the cache once invalidated")
+	}
+}
@@ -0,0 +1,31 @@
+package azurerm
+
+import "testing"
+
+func TestValidateStorageAccountKey(t *testing.T) {
+	cases := []struct {
+		Value    string
+		ErrCount int
+	}{
+		{
+			Value:    "ZXhhbXBsZS1rZXk=",
+			ErrCount: 0,
+		},
+		{
+			Value:    "not a key",
+			ErrCount: 1,
+		},
+		{
+			Value:    "ZXhhbXBsZS1rZXk",
+			ErrCount: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		_, errors := validateStorageAccountKey(tc.Value, "storage_account_key")
+
+		if len(errors) != tc.ErrCount {
+			t.Fatalf("Expected validateStorageAccountKey to trigger '%d' errors for %q - got '%d'", tc.ErrCount, tc.Value, len(errors))
+		}
+	}
+}
@@ -35,6 +35,8 @@ func tagsForDataSourceSchema() *schema.Schema {
 
 func tagValueToString(v interface{}) (string, error) {
 	switch value := v.(type) {
+	case nil:
+		return "", nil
 	case string:
 		return value, nil
 	case int:
@@ -67,6 +69,9 @@ func validateAzureRMTags(v interface{}, k string) (ws []string, es []error) {
 	return
 }
 
+// expandTags converts the tags from the config into the format used by the API. Every tag is
+// sent with a value - tags without a value (or with an empty value) are sent as an empty string,
+// rather than a nil value, which the API can reject.
 func expandTags(tagsMap map[string]interface{}) *map[string]*string {
 	output := make(map[string]*string, len(tagsMap))
 
@@ -88,7 +93,12 @@ func flattenAndSetTags(d *schema.ResourceData, tagsMap *map[string]*string) {
 	output := make(map[string]interface{}, len(*tagsMap))
 
 	for i, v := range *tagsMap {
-		output[i] = *v
+		// tags can be returned without a value, which we treat as an empty string
+		value := ""
+		if v != nil {
+			value = *v
+		}
+		output[i] = value
 	}
 
 	d.Set("tags", output)
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
 )
 
 func TestValidateMaximumNumberOfARMTags(t *testing.T) {
@@ -91,7 +94,64 @@ func TestExpandARMTags(t *testing.T) {
 		}
 
 		if *expanded[k] != strVal {
-			t.Fatalf("Expanded value %q incorrect: expected %q, got %q", k, strVal, expanded[k])
+			t.Fatalf("Expanded value %q incorrect: expected %q, got %q", k, strVal, *expanded[k])
+		}
+	}
+}
+
+func TestExpandARMTags_emptyValues(t *testing.T) {
+	testData := map[string]interface{}{
+		"empty": "",
+		"nil":   nil,
+	}
+
+	expanded := *expandTags(testData)
+
+	if len(expanded) != 2 {
+		t.Fatalf("Expected 2 results in expanded tag map, got %d", len(expanded))
+	}
+
+	for k := range testData {
+		if expanded[k] == nil {
+			t.Fatalf("Expected the value for %q to be an empty string but got nil", k)
+		}
+
+		if *expanded[k] != "" {
+			t.Fatalf("Expected the value for %q to be an empty string but got %q", k, *expanded[k])
+		}
+	}
+}
+
+func TestFlattenAndSetARMTags(t *testing.T) {
+	tagsMap := map[string]*string{
+		"simple":            utils.String("value1"),
+		"with.dots":         utils.String("value2"),
+		"with spaces":       utils.String("value3"),
+		"with.dots and spa": utils.String("value4"),
+		"empty":             nil,
+	}
+
+	d := schema.TestResourceDataRaw(t, resourceArmSnapshot().Schema, map[string]interface{}{})
+	flattenAndSetTags(d, &tagsMap)
+
+	flattened := d.Get("tags").(map[string]interface{})
+	if len(flattened) != len(tagsMap) {
+		t.Fatalf("Expected %d tags but got %d: %+v", len(tagsMap), len(flattened), flattened)
+	}
+
+	for k, v := range tagsMap {
+		expected := ""
+		if v != nil {
+			expected = *v
+		}
+
+		actual, ok := flattened[k]
+		if !ok {
+			t.Fatalf("Expected the tag %q to be set but it wasn't: %+v", k, flattened)
+		}
+
+		if actual.(string) != expected {
+			t.Fatalf("Expected the tag %q to have the value %q but got %q", k, expected, actual)
 		}
 	}
 }
@@ -0,0 +1,106 @@
+package azurerm
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+	"github.com/terraform-providers/terraform-provider-azurerm/azurerm/utils"
+)
+
+const (
+	// the number of times a throttled request is retried, on top of the retries made by autorest
+	throttlingMaxRetries = 6
+
+	throttlingBaseDelay = 2 * time.Second
+	throttlingMaxDelay  = 60 * time.Second
+)
+
+// transientConflictErrorCodes are the error codes returned alongside a 409 (Conflict) when another
+// operation is in progress on the resource - any other Conflict (e.g. the resource already exists) isn't retried.
+var transientConflictErrorCodes = []string{
+	"AnotherOperationInProgress",
+	"ConcurrentOperation",
+}
+
+// retryOnThrottling invokes the specified function, retrying with an exponential backoff whilst the
+// API returns a 429 (Too Many Requests) - in which case the Retry-After header is respected if present.
+// Any other error (or a throttled request which exceeds the number of retries) is returned as-is.
+func retryOnThrottling(f func() (autorest.Response, error)) error {
+	return retryWithBackoff(f, func(resp autorest.Response, _ error) bool {
+		return utils.ResponseWasThrottled(resp)
+	})
+}
+
+// retryOnThrottlingOrConflict behaves like retryOnThrottling, but also retries when the API returns a
+// 409 (Conflict) because another operation briefly holds a lock on a dependent resource (for example
+// when taking multiple Snapshots of the same Disk concurrently).
+func retryOnThrottlingOrConflict(f func() (autorest.Response, error)) error {
+	return retryWithBackoff(f, func(resp autorest.Response, err error) bool {
+		return utils.ResponseWasThrottled(resp) || (utils.ResponseWasConflict(resp) && errorWasTransientConflict(err))
+	})
+}
+
+func retryWithBackoff(f func() (autorest.Response, error), shouldRetry func(autorest.Response, error) bool) error {
+	for attempt := 0; ; attempt++ {
+		resp, err := f()
+		if err == nil || !shouldRetry(resp, err) || attempt >= throttlingMaxRetries {
+			return err
+		}
+
+		delay := throttlingRetryDelay(resp, attempt)
+		log.Printf("[DEBUG] Request returned a transient error (status %d) - retrying in %s (attempt %d of %d)", resp.StatusCode, delay, attempt+1, throttlingMaxRetries)
+		time.Sleep(delay)
+	}
+}
+
+// throttlingRetryDelay returns the duration to wait prior to retrying a throttled request, which is
+// taken from the Retry-After header if present - otherwise this backs off exponentially.
+func throttlingRetryDelay(resp autorest.Response, attempt int) time.Duration {
+	if resp.Response != nil {
+		if delay := autorest.GetRetryAfter(resp.Response, 0); delay > 0 {
+			return delay
+		}
+	}
+
+	delay := throttlingBaseDelay
+	for i := 0; i < attempt && delay < throttlingMaxDelay; i++ {
+		delay *= 2
+	}
+
+	if delay > throttlingMaxDelay {
+		delay = throttlingMaxDelay
+	}
+
+	return delay
+}
+
+// errorWasTransientConflict returns whether the error code returned by the API for a Conflict
+// is one of transientConflictErrorCodes.
+func errorWasTransientConflict(err error) bool {
+	if detailed, ok := err.(autorest.DetailedError); ok {
+		err = detailed.Original
+	}
+
+	var serviceError *azure.ServiceError
+	switch e := err.(type) {
+	case *azure.RequestError:
+		serviceError = e.ServiceError
+	case azure.RequestError:
+		serviceError = e.ServiceError
+	}
+
+	if serviceError == nil {
+		return false
+	}
+
+	for _, code := range transientConflictErrorCodes {
+		if strings.EqualFold(serviceError.Code, code) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,182 @@
+package azurerm
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Azure/go-autorest/autorest"
+	"github.com/Azure/go-autorest/autorest/azure"
+)
+
+func TestThrottlingRetryDelay(t *testing.T) {
+	testCases := []struct {
+		retryAfter string
+		attempt    int
+		expected   time.Duration
+	}{
+		{"", 0, 2 * time.Second},
+		{"", 1, 4 * time.Second},
+		{"", 3, 16 * time.Second},
+		{"", 5, 60 * time.Second},
+		{"", 20, 60 * time.Second},
+		{"17", 0, 17 * time.Second},
+		{"17", 4, 17 * time.Second},
+		{"0", 1, 4 * time.Second},
+		{"invalid", 0, 2 * time.Second},
+	}
+
+	for _, test := range testCases {
+		resp := autorest.Response{
+			Response: &http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Header:     http.Header{},
+			},
+		}
+		if test.retryAfter != "" {
+			resp.Header.Set("Retry-After", test.retryAfter)
+		}
+
+		if actual := throttlingRetryDelay(resp, test.attempt); actual != test.expected {
+			t.Fatalf("Expected a delay of %s for Retry-After %q and attempt %d but got %s", test.expected, test.retryAfter, test.attempt, actual)
+		}
+	}
+}
+
+func TestThrottlingRetryDelay_DroppedConnection(t *testing.T) {
+	if actual := throttlingRetryDelay(autorest.Response{}, 0); actual != throttlingBaseDelay {
+		t.Fatalf("Expected a delay of %s for a dropped connection but got %s", throttlingBaseDelay, actual)
+	}
+}
+
+func TestRetryOnThrottling_notThrottled(t *testing.T) {
+	testCases := []struct {
+		statusCode int
+		err        error
+	}{
+		{http.StatusOK, nil},
+		{http.StatusNotFound, fmt.Errorf("not found")},
+		{http.StatusInternalServerError, fmt.Errorf("internal server error")},
+	}
+
+	for _, test := range testCases {
+		calls := 0
+		err := retryOnThrottling(func() (autorest.Response, error) {
+			calls++
+			resp := autorest.Response{
+				Response: &http.Response{
+					StatusCode: test.statusCode,
+				},
+			}
+			return resp, test.err
+		})
+
+		if err != test.err {
+			t.Fatalf("Expected the error %+v but got %+v", test.err, err)
+		}
+
+		if calls != 1 {
+			t.Fatalf("Expected status code %d to be called once but it was called %d times", test.statusCode, calls)
+		}
+	}
+}
+
+func TestRetryOnThrottlingOrConflict_notRetried(t *testing.T) {
+	testCases := []struct {
+		statusCode int
+		err        error
+	}{
+		{http.StatusOK, nil},
+		{http.StatusBadRequest, fmt.Errorf("bad request")},
+		{http.StatusNotFound, fmt.Errorf("not found")},
+		{http.StatusConflict, fmt.Errorf("conflict")},
+		{http.StatusConflict, testConflictError("ResourceExists")},
+	}
+
+	for _, test := range testCases {
+		calls := 0
+		err := retryOnThrottlingOrConflict(func() (autorest.Response, error) {
+			calls++
+			resp := autorest.Response{
+				Response: &http.Response{
+					StatusCode: test.statusCode,
+				},
+			}
+			return resp, test.err
+		})
+
+		if !reflect.DeepEqual(err, test.err) {
+			t.Fatalf("Expected the error %+v but got %+v", test.err, err)
+		}
+
+		if calls != 1 {
+			t.Fatalf("Expected status code %d to be called once but it was called %d times", test.statusCode, calls)
+		}
+	}
+}
+
+func TestRetryOnThrottlingOrConflict_conflict(t *testing.T) {
+	calls := 0
+	err := retryOnThrottlingOrConflict(func() (autorest.Response, error) {
+		calls++
+		statusCode := http.StatusOK
+		var err error
+		if calls == 1 {
+			statusCode = http.StatusConflict
+			err = testConflictError("ConcurrentOperation")
+		}
+
+		resp := autorest.Response{
+			Response: &http.Response{
+				StatusCode: statusCode,
+				Header:     http.Header{},
+			},
+		}
+		resp.Header.Set("Retry-After", "0")
+		return resp, err
+	})
+
+	if err != nil {
+		t.Fatalf("Expected no error after retrying a conflict but got %+v", err)
+	}
+
+	if calls != 2 {
+		t.Fatalf("Expected a conflict to be retried once but it was called %d times", calls)
+	}
+}
+
+func TestErrorWasTransientConflict(t *testing.T) {
+	testCases := []struct {
+		err      error
+		expected bool
+	}{
+		{nil, false},
+		{fmt.Errorf("conflict"), false},
+		{testConflictError("ResourceExists"), false},
+		{testConflictError("ConcurrentOperation"), true},
+		{testConflictError("AnotherOperationInProgress"), true},
+		{testConflictError("concurrentoperation"), true},
+		{&azure.RequestError{ServiceError: &azure.ServiceError{Code: "ConcurrentOperation"}}, true},
+		{autorest.DetailedError{Original: fmt.Errorf("conflict")}, false},
+	}
+
+	for _, test := range testCases {
+		if actual := errorWasTransientConflict(test.err); actual != test.expected {
+			t.Fatalf("Expected the error %+v to be a transient conflict %t but got %t", test.err, test.expected, actual)
+		}
+	}
+}
+
+// testConflictError returns an error in the format returned by the SDK when the API returns a Conflict
+func testConflictError(code string) error {
+	return autorest.DetailedError{
+		Original: &azure.RequestError{
+			ServiceError: &azure.ServiceError{
+				Code: code,
+			},
+		},
+		StatusCode: http.StatusConflict,
+	}
+}
@@ -14,6 +14,10 @@ func ResponseWasNotFound(resp autorest.Response) bool {
 	return responseWasStatusCode(resp, http.StatusNotFound)
 }
 
+func ResponseWasThrottled(resp autorest.Response) bool {
+	return responseWasStatusCode(resp, http.StatusTooManyRequests)
+}
+
 func responseWasStatusCode(resp autorest.Response, statusCode int) bool {
 	if r := resp.Response; r != nil {
 		if r.StatusCode == statusCode {
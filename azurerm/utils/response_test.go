@@ -37,3 +37,27 @@ func TestResponseNotFound_StatusCodes(t *testing.T) {
 		}
 	}
 }
+
+func TestResponseWasThrottled_StatusCodes(t *testing.T) {
+	testCases := []struct {
+		statusCode     int
+		expectedResult bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusServiceUnavailable, false},
+		{http.StatusTooManyRequests, true},
+	}
+
+	for _, test := range testCases {
+		resp := autorest.Response{
+			Response: &http.Response{
+				StatusCode: test.statusCode,
+			},
+		}
+		result := ResponseWasThrottled(resp)
+		if test.expectedResult != result {
+			t.Fatalf("Expected '%+v' for status code '%d' - got '%+v'",
+				test.expectedResult, test.statusCode, result)
+		}
+	}
+}